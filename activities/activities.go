@@ -4,14 +4,26 @@ import (
 	"bytes"
 	"context"
 	_ "embed"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"net/url"
+	"strconv"
 	"text/template"
 	"time"
 
 	mail "github.com/xhit/go-simple-mail/v2"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/temporal"
+
+	"github.com/temporalio/background-checks/watchlist"
 )
 
 const (
@@ -22,7 +34,17 @@ const (
 
 	federalCriminalSearchAPITimeout = time.Second * 5
 	stateCriminalSearchAPITimeout   = time.Second * 5
+	educationRecordSearchAPITimeout = time.Second * 5
+	licenseRecordSearchAPITimeout   = time.Second * 5
+	drugScreenAPITimeout            = time.Second * 5
+	sexOffenderRegistryAPITimeout   = time.Second * 5
+	countryCriminalSearchAPITimeout = time.Second * 5
+	countyCriminalSearchAPITimeout  = time.Second * 5
+	creditReportAPITimeout          = time.Second * 5
 	ssnTraceAPITimeout              = time.Second * 5
+
+	// drugScreenCallbackURL is where the lab sends drug screen results. The API completes the waiting activity.
+	drugScreenCallbackURL = "http://api:8081/webhooks/drugscreen/%s"
 )
 
 type Activities struct {
@@ -30,12 +52,64 @@ type Activities struct {
 	SMTPPort int
 	SMTPStub bool
 	HTTPStub bool
+	// Watchlist is the sanctions and watch list that candidates are screened against.
+	Watchlist *watchlist.Source
+	// Client is used to look up the state of other workflows.
+	Client client.Client
 }
 
 type PostJSONOptions struct {
 	Timeout time.Duration
 }
 
+const (
+	// VendorErrorRejected is returned when a vendor rejects our request as invalid. Retrying won't help.
+	VendorErrorRejected = "VendorRejected"
+	// VendorErrorUnavailable is returned when a vendor fails, or can't be reached. The request may be retried.
+	VendorErrorUnavailable = "VendorUnavailable"
+	// VendorErrorRateLimited is returned when a vendor asks us to slow down. The request may be retried, and if the
+	// vendor said when, the error's details hold how long to wait.
+	VendorErrorRateLimited = "VendorRateLimited"
+	// VendorErrorTimeout is returned when a vendor doesn't respond in time. The request may be retried.
+	VendorErrorTimeout = "VendorTimeout"
+)
+
+// retryAfter returns how long a vendor asked us to wait before retrying, from the Retry-After header.
+// Only the number of seconds form is supported, as that is what our vendors send.
+func retryAfter(r *http.Response) time.Duration {
+	seconds, err := strconv.Atoi(r.Header.Get("Retry-After"))
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// vendorError classifies an unsuccessful response from a vendor, so that the activity is only retried if that
+// could help. Requests the vendor rejects as invalid are not retried, while vendor failures are retried with the
+// backoff in the activity's retry policy.
+//
+// When the vendor is rate limiting us and says how long to wait, the attempt fails without being retried, with the
+// delay in the error's details. An activity can't choose when it is retried, so the search workflow waits out the
+// delay and runs the activity again. Without a Retry-After header the attempt is retried with the usual backoff.
+func vendorError(r *http.Response) error {
+	body, _ := io.ReadAll(r.Body)
+	message := fmt.Sprintf("%s: %s", http.StatusText(r.StatusCode), body)
+
+	switch {
+	case r.StatusCode == http.StatusTooManyRequests:
+		if delay := retryAfter(r); delay > 0 {
+			return temporal.NewNonRetryableApplicationError(message, VendorErrorRateLimited, nil, delay)
+		}
+		return temporal.NewApplicationError(message, VendorErrorRateLimited)
+	case r.StatusCode == http.StatusRequestTimeout || r.StatusCode == http.StatusGatewayTimeout:
+		return temporal.NewApplicationError(message, VendorErrorTimeout)
+	case r.StatusCode >= 400 && r.StatusCode < 500:
+		return temporal.NewNonRetryableApplicationError(message, VendorErrorRejected, nil)
+	default:
+		return temporal.NewApplicationError(message, VendorErrorUnavailable)
+	}
+}
+
 func (a *Activities) sendMail(from string, to string, subject string, htmlTemplate *template.Template, textTemplate *template.Template, input interface{}) error {
 	var htmlContent, textContent bytes.Buffer
 
@@ -95,7 +169,16 @@ func (a *Activities) postJSON(ctx context.Context, url string, input interface{}
 		Timeout: options.Timeout,
 	}
 
-	return client.Do(req)
+	r, err := client.Do(req)
+	if err != nil {
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			return nil, temporal.NewApplicationErrorWithCause("vendor did not respond in time", VendorErrorTimeout, err)
+		}
+		return nil, temporal.NewApplicationErrorWithCause("unable to reach vendor", VendorErrorUnavailable, err)
+	}
+
+	return r, nil
 }
 
 type FederalCriminalSearchInput struct {
@@ -121,9 +204,7 @@ func (a *Activities) FederalCriminalSearch(ctx context.Context, input *FederalCr
 	defer r.Body.Close()
 
 	if r.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(r.Body)
-
-		return &result, fmt.Errorf("%s: %s", http.StatusText(r.StatusCode), body)
+		return &result, vendorError(r)
 	}
 
 	err = json.NewDecoder(r.Body).Decode(&result)
@@ -152,6 +233,51 @@ func (a *Activities) SendAcceptEmail(ctx context.Context, input *SendAcceptEmail
 	return &result, err
 }
 
+//go:embed accept_reminder_email.go.html
+var acceptReminderEmailHTML string
+var acceptReminderEmailHTMLTemplate = template.Must(template.New("acceptReminderEmailHTML").Parse(acceptReminderEmailHTML))
+
+//go:embed accept_reminder_email.go.tmpl
+var acceptReminderEmailText string
+var acceptReminderEmailTextTemplate = template.Must(template.New("acceptReminderEmailText").Parse(acceptReminderEmailText))
+
+type SendAcceptReminderEmailInput struct {
+	Email    string
+	Token    string
+	Deadline time.Time
+}
+
+type SendAcceptReminderEmailResult struct{}
+
+func (a *Activities) SendAcceptReminderEmail(ctx context.Context, input *SendAcceptReminderEmailInput) (*SendAcceptReminderEmailResult, error) {
+	var result SendAcceptReminderEmailResult
+
+	err := a.sendMail(CandidateSupportEmail, input.Email, "Reminder: Background Check Request", acceptReminderEmailHTMLTemplate, acceptReminderEmailTextTemplate, input)
+	return &result, err
+}
+
+//go:embed candidate_unresponsive_email.go.html
+var candidateUnresponsiveEmailHTML string
+var candidateUnresponsiveEmailHTMLTemplate = template.Must(template.New("candidateUnresponsiveEmailHTML").Parse(candidateUnresponsiveEmailHTML))
+
+//go:embed candidate_unresponsive_email.go.tmpl
+var candidateUnresponsiveEmailText string
+var candidateUnresponsiveEmailTextTemplate = template.Must(template.New("candidateUnresponsiveEmailText").Parse(candidateUnresponsiveEmailText))
+
+type SendCandidateUnresponsiveEmailInput struct {
+	Email    string
+	Deadline time.Time
+}
+
+type SendCandidateUnresponsiveEmailResult struct{}
+
+func (a *Activities) SendCandidateUnresponsiveEmail(ctx context.Context, input *SendCandidateUnresponsiveEmailInput) (*SendCandidateUnresponsiveEmailResult, error) {
+	var result SendCandidateUnresponsiveEmailResult
+
+	err := a.sendMail(HiringSupportEmail, HiringManagerEmail, "Background Check Candidate Unresponsive", candidateUnresponsiveEmailHTMLTemplate, candidateUnresponsiveEmailTextTemplate, input)
+	return &result, err
+}
+
 //go:embed decline_email.go.html
 var declineEmailHTML string
 var declineEmailHTMLTemplate = template.Must(template.New("declineEmailHTML").Parse(declineEmailHTML))
@@ -161,7 +287,8 @@ var declineEmailText string
 var declineEmailTextTemplate = template.Must(template.New("declineEmailText").Parse(declineEmailText))
 
 type SendDeclineEmailInput struct {
-	Email string
+	Email   string
+	Expired bool
 }
 
 type SendDeclineEmailResult struct{}
@@ -169,7 +296,47 @@ type SendDeclineEmailResult struct{}
 func (a *Activities) SendDeclineEmail(ctx context.Context, input *SendDeclineEmailInput) (*SendDeclineEmailResult, error) {
 	var result SendDeclineEmailResult
 
-	err := a.sendMail(HiringSupportEmail, HiringManagerEmail, "Background Check Declined", declineEmailHTMLTemplate, declineEmailTextTemplate, input)
+	subject := "Background Check Declined"
+	if input.Expired {
+		subject = "Background Check Expired"
+	}
+
+	err := a.sendMail(HiringSupportEmail, HiringManagerEmail, subject, declineEmailHTMLTemplate, declineEmailTextTemplate, input)
+	return &result, err
+}
+
+//go:embed cancellation_email.go.html
+var cancellationEmailHTML string
+var cancellationEmailHTMLTemplate = template.Must(template.New("cancellationEmailHTML").Parse(cancellationEmailHTML))
+
+//go:embed cancellation_email.go.tmpl
+var cancellationEmailText string
+var cancellationEmailTextTemplate = template.Must(template.New("cancellationEmailText").Parse(cancellationEmailText))
+
+type SendCancellationEmailInput struct {
+	Email       string
+	CancelledBy string
+	Reason      string
+}
+
+type SendCancellationEmailResult struct{}
+
+// cancellationEmail is the data for the cancellation email templates, which differ for the candidate.
+type cancellationEmail struct {
+	SendCancellationEmailInput
+	Candidate bool
+}
+
+// SendCancellationEmail lets the Hiring Manager and the candidate know that a background check has been cancelled.
+func (a *Activities) SendCancellationEmail(ctx context.Context, input *SendCancellationEmailInput) (*SendCancellationEmailResult, error) {
+	var result SendCancellationEmailResult
+
+	err := a.sendMail(HiringSupportEmail, HiringManagerEmail, "Background Check Cancelled", cancellationEmailHTMLTemplate, cancellationEmailTextTemplate, cancellationEmail{SendCancellationEmailInput: *input})
+	if err != nil {
+		return &result, err
+	}
+
+	err = a.sendMail(CandidateSupportEmail, input.Email, "Background Check Cancelled", cancellationEmailHTMLTemplate, cancellationEmailTextTemplate, cancellationEmail{SendCancellationEmailInput: *input, Candidate: true})
 	return &result, err
 }
 
@@ -182,8 +349,9 @@ var employmentVerificationRequestEmailText string
 var employmentVerificationRequestEmailTextTemplate = template.Must(template.New("employmentVerificationRequestEmailText").Parse(employmentVerificationRequestEmailText))
 
 type SendEmploymentVerificationEmailInput struct {
-	Email string
-	Token string
+	Email    string
+	Token    string
+	Reminder bool
 }
 
 type SendEmploymentVerificationEmailResult struct{}
@@ -191,7 +359,12 @@ type SendEmploymentVerificationEmailResult struct{}
 func (a *Activities) SendEmploymentVerificationRequestEmail(ctx context.Context, input *SendEmploymentVerificationEmailInput) (*SendEmploymentVerificationEmailResult, error) {
 	var result SendEmploymentVerificationEmailResult
 
-	err := a.sendMail(ResearcherSupportEmail, input.Email, "Employment Verification Request", employmentVerificationRequestEmailHTMLTemplate, employmentVerificationRequestEmailTextTemplate, input)
+	subject := "Employment Verification Request"
+	if input.Reminder {
+		subject = "Reminder: Employment Verification Request"
+	}
+
+	err := a.sendMail(ResearcherSupportEmail, input.Email, subject, employmentVerificationRequestEmailHTMLTemplate, employmentVerificationRequestEmailTextTemplate, input)
 
 	return &result, err
 }
@@ -205,8 +378,13 @@ var reportEmailText string
 var reportEmailTextTemplate = template.Must(template.New("reportEmailText").Parse(reportEmailText))
 
 type SendReportEmailInput struct {
-	Email string
-	Token string
+	Email   string
+	Token   string
+	Amended bool
+	// Outstanding lists the searches that had not completed when the report was sent, if it was released early.
+	Outstanding []string
+	// LateSearch is the search whose result the report was amended with, if it completed after the report was sent.
+	LateSearch string
 }
 
 type SendReportEmailResult struct{}
@@ -218,14 +396,152 @@ func (a *Activities) SendReportEmail(ctx context.Context, input *SendReportEmail
 	return &result, err
 }
 
+//go:embed sla_warning_email.go.html
+var slaWarningEmailHTML string
+var slaWarningEmailHTMLTemplate = template.Must(template.New("slaWarningEmailHTML").Parse(slaWarningEmailHTML))
+
+//go:embed sla_warning_email.go.tmpl
+var slaWarningEmailText string
+var slaWarningEmailTextTemplate = template.Must(template.New("slaWarningEmailText").Parse(slaWarningEmailText))
+
+type SendSLAWarningEmailInput struct {
+	Email       string
+	Token       string
+	Deadline    time.Time
+	Outstanding []string
+}
+
+type SendSLAWarningEmailResult struct{}
+
+// SendSLAWarningEmail lets the Hiring Manager and our support team know that a background check is running late,
+// so that support can chase the outstanding searches.
+func (a *Activities) SendSLAWarningEmail(ctx context.Context, input *SendSLAWarningEmailInput) (*SendSLAWarningEmailResult, error) {
+	var result SendSLAWarningEmailResult
+
+	for _, to := range []string{HiringManagerEmail, HiringSupportEmail} {
+		err := a.sendMail(HiringSupportEmail, to, "Background Check Delayed", slaWarningEmailHTMLTemplate, slaWarningEmailTextTemplate, input)
+		if err != nil {
+			return &result, err
+		}
+	}
+
+	return &result, nil
+}
+
+//go:embed pre_adverse_action_email.go.html
+var preAdverseActionEmailHTML string
+var preAdverseActionEmailHTMLTemplate = template.Must(template.New("preAdverseActionEmailHTML").Parse(preAdverseActionEmailHTML))
+
+//go:embed pre_adverse_action_email.go.tmpl
+var preAdverseActionEmailText string
+var preAdverseActionEmailTextTemplate = template.Must(template.New("preAdverseActionEmailText").Parse(preAdverseActionEmailText))
+
+type SendPreAdverseActionEmailInput struct {
+	Email string
+	Token string
+}
+
+type SendPreAdverseActionEmailResult struct{}
+
+func (a *Activities) SendPreAdverseActionEmail(ctx context.Context, input *SendPreAdverseActionEmailInput) (*SendPreAdverseActionEmailResult, error) {
+	var result SendPreAdverseActionEmailResult
+
+	err := a.sendMail(CandidateSupportEmail, input.Email, "Pre-Adverse Action Notice", preAdverseActionEmailHTMLTemplate, preAdverseActionEmailTextTemplate, input)
+	return &result, err
+}
+
+//go:embed adverse_action_email.go.html
+var adverseActionEmailHTML string
+var adverseActionEmailHTMLTemplate = template.Must(template.New("adverseActionEmailHTML").Parse(adverseActionEmailHTML))
+
+//go:embed adverse_action_email.go.tmpl
+var adverseActionEmailText string
+var adverseActionEmailTextTemplate = template.Must(template.New("adverseActionEmailText").Parse(adverseActionEmailText))
+
+type SendAdverseActionEmailInput struct {
+	Email string
+	Token string
+}
+
+type SendAdverseActionEmailResult struct{}
+
+func (a *Activities) SendAdverseActionEmail(ctx context.Context, input *SendAdverseActionEmailInput) (*SendAdverseActionEmailResult, error) {
+	var result SendAdverseActionEmailResult
+
+	err := a.sendMail(CandidateSupportEmail, input.Email, "Adverse Action Notice", adverseActionEmailHTMLTemplate, adverseActionEmailTextTemplate, input)
+	return &result, err
+}
+
+//go:embed dispute_result_email.go.html
+var disputeResultEmailHTML string
+var disputeResultEmailHTMLTemplate = template.Must(template.New("disputeResultEmailHTML").Parse(disputeResultEmailHTML))
+
+//go:embed dispute_result_email.go.tmpl
+var disputeResultEmailText string
+var disputeResultEmailTextTemplate = template.Must(template.New("disputeResultEmailText").Parse(disputeResultEmailText))
+
+type SendDisputeResultEmailInput struct {
+	Email      string
+	Token      string
+	SearchName string
+	Finding    string
+	Outcome    string
+}
+
+type SendDisputeResultEmailResult struct{}
+
+func (a *Activities) SendDisputeResultEmail(ctx context.Context, input *SendDisputeResultEmailInput) (*SendDisputeResultEmailResult, error) {
+	var result SendDisputeResultEmailResult
+
+	err := a.sendMail(CandidateSupportEmail, input.Email, "Background Check Dispute Result", disputeResultEmailHTMLTemplate, disputeResultEmailTextTemplate, input)
+	return &result, err
+}
+
+//go:embed monitoring_alert_email.go.html
+var monitoringAlertEmailHTML string
+var monitoringAlertEmailHTMLTemplate = template.Must(template.New("monitoringAlertEmailHTML").Parse(monitoringAlertEmailHTML))
+
+//go:embed monitoring_alert_email.go.tmpl
+var monitoringAlertEmailText string
+var monitoringAlertEmailTextTemplate = template.Must(template.New("monitoringAlertEmailText").Parse(monitoringAlertEmailText))
+
+type SendMonitoringAlertEmailInput struct {
+	Email       string
+	NewFindings map[string][]string
+	// Incomplete lists what each search that could not be completed this cycle missed.
+	Incomplete map[string][]string
+}
+
+type SendMonitoringAlertEmailResult struct{}
+
+func (a *Activities) SendMonitoringAlertEmail(ctx context.Context, input *SendMonitoringAlertEmailInput) (*SendMonitoringAlertEmailResult, error) {
+	var result SendMonitoringAlertEmailResult
+
+	err := a.sendMail(HiringSupportEmail, HiringManagerEmail, "Continuous Monitoring Alert", monitoringAlertEmailHTMLTemplate, monitoringAlertEmailTextTemplate, input)
+	return &result, err
+}
+
 type SSNTraceInput struct {
 	FullName string
 	SSN      string
 }
 
+// SSNTraceAddress is an address linked to an SSN, as the trace vendor returns it.
+// From and To are dates in the form YYYY-MM-DD. To is blank for the current address.
+type SSNTraceAddress struct {
+	Address string
+	From    string
+	To      string
+}
+
 type SSNTraceResult struct {
 	SSNIsValid     bool
-	KnownAddresses []string
+	KnownAddresses []SSNTraceAddress
+	// NamesOnRecord, DOBOnRecord and IssuedYear are the identity the SSN is registered to.
+	// They are empty if the SSN administration has no record of the SSN.
+	NamesOnRecord []string
+	DOBOnRecord   string
+	IssuedYear    int
 }
 
 func (a *Activities) SSNTrace(ctx context.Context, input *SSNTraceInput) (*SSNTraceResult, error) {
@@ -244,9 +560,7 @@ func (a *Activities) SSNTrace(ctx context.Context, input *SSNTraceInput) (*SSNTr
 
 	if r.StatusCode != http.StatusOK {
 		defer r.Body.Close()
-		body, _ := io.ReadAll(r.Body)
-
-		return &result, fmt.Errorf("%s: %s", http.StatusText(r.StatusCode), body)
+		return &result, vendorError(r)
 	}
 
 	err = json.NewDecoder(r.Body).Decode(&result)
@@ -278,9 +592,7 @@ func (a *Activities) StateCriminalSearch(ctx context.Context, input *StateCrimin
 	defer r.Body.Close()
 
 	if r.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(r.Body)
-
-		return &result, fmt.Errorf("%s: %s", http.StatusText(r.StatusCode), body)
+		return &result, vendorError(r)
 	}
 
 	err = json.NewDecoder(r.Body).Decode(&result)
@@ -311,11 +623,478 @@ func (a *Activities) MotorVehicleIncidentSearch(ctx context.Context, input *Moto
 	defer r.Body.Close()
 
 	if r.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(r.Body)
+		return &result, vendorError(r)
+	}
 
-		return &result, fmt.Errorf("%s: %s", http.StatusText(r.StatusCode), body)
+	err = json.NewDecoder(r.Body).Decode(&result)
+	return &result, err
+}
+
+type EducationRecordSearchInput struct {
+	FullName    string
+	Institution string
+}
+
+type EducationRecordSearchResult struct {
+	RecordFound    bool
+	Degree         string
+	GraduationDate string
+}
+
+func (a *Activities) EducationRecordSearch(ctx context.Context, input *EducationRecordSearchInput) (*EducationRecordSearchResult, error) {
+	var result EducationRecordSearchResult
+
+	if a.HTTPStub {
+		return &result, nil
+	}
+
+	r, err := a.postJSON(ctx, "http://thirdparty:8082/educationverification", input, PostJSONOptions{Timeout: educationRecordSearchAPITimeout})
+	if err != nil {
+		return &result, err
+	}
+	defer r.Body.Close()
+
+	if r.StatusCode != http.StatusOK {
+		return &result, vendorError(r)
 	}
 
 	err = json.NewDecoder(r.Body).Decode(&result)
 	return &result, err
 }
+
+type LicenseRecordSearchInput struct {
+	FullName string
+	Type     string
+	Number   string
+	State    string
+}
+
+type LicenseRecordSearchResult struct {
+	Found     bool
+	Status    string
+	Expires   string
+	Sanctions []string
+}
+
+func (a *Activities) LicenseRecordSearch(ctx context.Context, input *LicenseRecordSearchInput) (*LicenseRecordSearchResult, error) {
+	var result LicenseRecordSearchResult
+
+	if a.HTTPStub {
+		return &result, nil
+	}
+
+	r, err := a.postJSON(ctx, "http://thirdparty:8082/professionallicensesearch", input, PostJSONOptions{Timeout: licenseRecordSearchAPITimeout})
+	if err != nil {
+		return &result, err
+	}
+	defer r.Body.Close()
+
+	if r.StatusCode != http.StatusOK {
+		return &result, vendorError(r)
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&result)
+	return &result, err
+}
+
+type SexOffenderRegistryLookupInput struct {
+	FullName string
+	DOB      string
+}
+
+// SexOffenderRegistryMatch is a registry entry that may be the candidate.
+// Confidence is between 0 and 1, and reflects how closely the entry's name and date of birth match the candidate's.
+type SexOffenderRegistryMatch struct {
+	Name       string
+	DOB        string
+	State      string
+	Offense    string
+	Confidence float64
+}
+
+type SexOffenderRegistryLookupResult struct {
+	Matches []SexOffenderRegistryMatch
+}
+
+func (a *Activities) SexOffenderRegistryLookup(ctx context.Context, input *SexOffenderRegistryLookupInput) (*SexOffenderRegistryLookupResult, error) {
+	var result SexOffenderRegistryLookupResult
+
+	if a.HTTPStub {
+		return &result, nil
+	}
+
+	r, err := a.postJSON(ctx, "http://thirdparty:8082/sexoffenderregistry", input, PostJSONOptions{Timeout: sexOffenderRegistryAPITimeout})
+	if err != nil {
+		return &result, err
+	}
+	defer r.Body.Close()
+
+	if r.StatusCode != http.StatusOK {
+		return &result, vendorError(r)
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&result)
+	return &result, err
+}
+
+type CountyCriminalRecordSearchInput struct {
+	FullName string
+	County   string
+	State    string
+	// From and To limit the search to records filed between the two dates, as YYYY-MM-DD.
+	From string
+	To   string
+}
+
+type CountyCriminalRecordSearchResult struct {
+	Crimes []string
+}
+
+func (a *Activities) CountyCriminalRecordSearch(ctx context.Context, input *CountyCriminalRecordSearchInput) (*CountyCriminalRecordSearchResult, error) {
+	var result CountyCriminalRecordSearchResult
+
+	if a.HTTPStub {
+		return &result, nil
+	}
+
+	r, err := a.postJSON(ctx, "http://thirdparty:8082/countycriminalsearch", input, PostJSONOptions{Timeout: countyCriminalSearchAPITimeout})
+	if err != nil {
+		return &result, err
+	}
+	defer r.Body.Close()
+
+	if r.StatusCode != http.StatusOK {
+		return &result, vendorError(r)
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&result)
+	return &result, err
+}
+
+type CountryCriminalRecordSearchInput struct {
+	FullName string
+	Country  string
+}
+
+type CountryCriminalRecordSearchResult struct {
+	// Available is false if the vendor is unable to search records in the country.
+	Available bool
+	Crimes    []string
+}
+
+// ErrCountryCriminalSearchPending is returned while the vendor is still searching the country's records,
+// so that the activity is retried until the result is ready.
+var ErrCountryCriminalSearchPending = errors.New("country criminal search is still in progress")
+
+// CountryCriminalRecordSearch checks on a criminal record search with the international vendor, starting it if
+// needed. Searches abroad take days, so this returns ErrCountryCriminalSearchPending until the search is complete.
+func (a *Activities) CountryCriminalRecordSearch(ctx context.Context, input *CountryCriminalRecordSearchInput) (*CountryCriminalRecordSearchResult, error) {
+	var result CountryCriminalRecordSearchResult
+
+	if a.HTTPStub {
+		result.Available = true
+		return &result, nil
+	}
+
+	r, err := a.postJSON(ctx, fmt.Sprintf("http://thirdparty:8082/internationalcriminalsearch/%s", url.PathEscape(input.Country)), input, PostJSONOptions{Timeout: countryCriminalSearchAPITimeout})
+	if err != nil {
+		return &result, err
+	}
+	defer r.Body.Close()
+
+	if r.StatusCode == http.StatusNotFound {
+		return &result, nil
+	}
+	if r.StatusCode != http.StatusOK {
+		return &result, vendorError(r)
+	}
+
+	var status struct {
+		Complete bool
+		Crimes   []string
+	}
+	err = json.NewDecoder(r.Body).Decode(&status)
+	if err != nil {
+		return &result, err
+	}
+	if !status.Complete {
+		return &result, ErrCountryCriminalSearchPending
+	}
+
+	result.Available = true
+	result.Crimes = status.Crimes
+
+	return &result, nil
+}
+
+type WatchlistScreenInput struct {
+	Names []string
+}
+
+type WatchlistScreenResult struct {
+	Hits []watchlist.Hit
+}
+
+// WatchlistScreen screens each of the candidate's names against the sanctions and watch lists.
+func (a *Activities) WatchlistScreen(ctx context.Context, input *WatchlistScreenInput) (*WatchlistScreenResult, error) {
+	var result WatchlistScreenResult
+
+	if a.Watchlist == nil {
+		return &result, errors.New("no watch list has been configured")
+	}
+
+	hits, err := a.Watchlist.Screen(input.Names, watchlist.DefaultThreshold)
+	result.Hits = hits
+	return &result, err
+}
+
+type CreditReportLookupInput struct {
+	FullName string
+	SSN      string
+	DOB      string
+}
+
+// TradeLine is a credit account on the candidate's credit file.
+type TradeLine struct {
+	Creditor string
+	Type     string
+	Opened   string
+	Balance  int
+	Limit    int
+	Status   string
+}
+
+// Collection is a debt that has been passed to a collection agency.
+type Collection struct {
+	Agency           string
+	OriginalCreditor string
+	Amount           int
+	Reported         string
+}
+
+type Bankruptcy struct {
+	Chapter int
+	Court   string
+	Filed   string
+	Status  string
+}
+
+type CreditReportLookupResult struct {
+	TradeLines   []TradeLine
+	Collections  []Collection
+	Bankruptcies []Bankruptcy
+}
+
+func (a *Activities) CreditReportLookup(ctx context.Context, input *CreditReportLookupInput) (*CreditReportLookupResult, error) {
+	var result CreditReportLookupResult
+
+	if a.HTTPStub {
+		return &result, nil
+	}
+
+	r, err := a.postJSON(ctx, "http://thirdparty:8082/creditreport", input, PostJSONOptions{Timeout: creditReportAPITimeout})
+	if err != nil {
+		return &result, err
+	}
+	defer r.Body.Close()
+
+	if r.StatusCode != http.StatusOK {
+		return &result, vendorError(r)
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&result)
+	return &result, err
+}
+
+type OrderDrugScreenInput struct {
+	FullName string
+	Email    string
+}
+
+type OrderDrugScreenResult struct {
+	OrderID        string
+	AppointmentURL string
+}
+
+// OrderDrugScreen orders a drug test for the candidate from the lab.
+// The lab returns a link the candidate can use to book a collection site appointment.
+func (a *Activities) OrderDrugScreen(ctx context.Context, input *OrderDrugScreenInput) (*OrderDrugScreenResult, error) {
+	var result OrderDrugScreenResult
+
+	if a.HTTPStub {
+		return &result, nil
+	}
+
+	r, err := a.postJSON(ctx, "http://thirdparty:8082/drugscreen/orders", input, PostJSONOptions{Timeout: drugScreenAPITimeout})
+	if err != nil {
+		return &result, err
+	}
+	defer r.Body.Close()
+
+	if r.StatusCode != http.StatusOK {
+		return &result, vendorError(r)
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&result)
+	return &result, err
+}
+
+type AwaitDrugScreenResultInput struct {
+	OrderID string
+}
+
+// DrugScreenLabResult is the result the lab posts back once the candidate's sample has been tested.
+type DrugScreenLabResult struct {
+	OrderID    string
+	Result     string
+	Substances []string
+}
+
+// AwaitDrugScreenResult asks the lab to send the result of a drug screen to our webhook, and then waits for it.
+// The activity completes asynchronously: the webhook completes it using the activity's task token,
+// which may be days later once the candidate has visited the collection site.
+func (a *Activities) AwaitDrugScreenResult(ctx context.Context, input *AwaitDrugScreenResultInput) (*DrugScreenLabResult, error) {
+	var result DrugScreenLabResult
+
+	if a.HTTPStub {
+		return &result, nil
+	}
+
+	token := base64.URLEncoding.EncodeToString(activity.GetInfo(ctx).TaskToken)
+	callback := map[string]string{"CallbackURL": fmt.Sprintf(drugScreenCallbackURL, token)}
+
+	r, err := a.postJSON(ctx, fmt.Sprintf("http://thirdparty:8082/drugscreen/orders/%s/callback", input.OrderID), callback, PostJSONOptions{Timeout: drugScreenAPITimeout})
+	if err != nil {
+		return &result, err
+	}
+	defer r.Body.Close()
+
+	if r.StatusCode != http.StatusOK {
+		return &result, vendorError(r)
+	}
+
+	return &result, activity.ErrResultPending
+}
+
+//go:embed drug_screen_appointment_email.go.html
+var drugScreenAppointmentEmailHTML string
+var drugScreenAppointmentEmailHTMLTemplate = template.Must(template.New("drugScreenAppointmentEmailHTML").Parse(drugScreenAppointmentEmailHTML))
+
+//go:embed drug_screen_appointment_email.go.tmpl
+var drugScreenAppointmentEmailText string
+var drugScreenAppointmentEmailTextTemplate = template.Must(template.New("drugScreenAppointmentEmailText").Parse(drugScreenAppointmentEmailText))
+
+type SendDrugScreenAppointmentEmailInput struct {
+	Email          string
+	AppointmentURL string
+}
+
+type SendDrugScreenAppointmentEmailResult struct{}
+
+func (a *Activities) SendDrugScreenAppointmentEmail(ctx context.Context, input *SendDrugScreenAppointmentEmailInput) (*SendDrugScreenAppointmentEmailResult, error) {
+	var result SendDrugScreenAppointmentEmailResult
+
+	err := a.sendMail(CandidateSupportEmail, input.Email, "Drug Screen Appointment", drugScreenAppointmentEmailHTMLTemplate, drugScreenAppointmentEmailTextTemplate, input)
+	return &result, err
+}
+
+//go:embed reference_request_email.go.html
+var referenceRequestEmailHTML string
+var referenceRequestEmailHTMLTemplate = template.Must(template.New("referenceRequestEmailHTML").Parse(referenceRequestEmailHTML))
+
+//go:embed reference_request_email.go.tmpl
+var referenceRequestEmailText string
+var referenceRequestEmailTextTemplate = template.Must(template.New("referenceRequestEmailText").Parse(referenceRequestEmailText))
+
+type SendReferenceRequestEmailInput struct {
+	Email         string
+	Name          string
+	CandidateName string
+	Token         string
+	Reminder      bool
+}
+
+type SendReferenceRequestEmailResult struct{}
+
+func (a *Activities) SendReferenceRequestEmail(ctx context.Context, input *SendReferenceRequestEmailInput) (*SendReferenceRequestEmailResult, error) {
+	var result SendReferenceRequestEmailResult
+
+	subject := fmt.Sprintf("Reference Request for %s", input.CandidateName)
+	if input.Reminder {
+		subject = "Reminder: " + subject
+	}
+
+	err := a.sendMail(HiringSupportEmail, input.Email, subject, referenceRequestEmailHTMLTemplate, referenceRequestEmailTextTemplate, input)
+	return &result, err
+}
+
+//go:embed researcher_review_request.go.html
+var researcherReviewRequestEmailHTML string
+var researcherReviewRequestEmailHTMLTemplate = template.Must(template.New("researcherReviewRequestEmailHTML").Parse(researcherReviewRequestEmailHTML))
+
+//go:embed researcher_review_request.go.tmpl
+var researcherReviewRequestEmailText string
+var researcherReviewRequestEmailTextTemplate = template.Must(template.New("researcherReviewRequestEmailText").Parse(researcherReviewRequestEmailText))
+
+type SendResearcherReviewRequestEmailInput struct {
+	Email    string
+	Token    string
+	Subject  string
+	Reminder bool
+}
+
+type SendResearcherReviewRequestEmailResult struct{}
+
+func (a *Activities) SendResearcherReviewRequestEmail(ctx context.Context, input *SendResearcherReviewRequestEmailInput) (*SendResearcherReviewRequestEmailResult, error) {
+	var result SendResearcherReviewRequestEmailResult
+
+	subject := fmt.Sprintf("%s Request", input.Subject)
+	if input.Reminder {
+		subject = "Reminder: " + subject
+	}
+
+	err := a.sendMail(ResearcherSupportEmail, input.Email, subject, researcherReviewRequestEmailHTMLTemplate, researcherReviewRequestEmailTextTemplate, input)
+	return &result, err
+}
+
+// WorkflowExecution identifies a single run of a workflow.
+type WorkflowExecution struct {
+	WorkflowID string
+	RunID      string
+}
+
+type ClosedWorkflowsInput struct {
+	Executions []WorkflowExecution
+}
+
+type ClosedWorkflowsResult struct {
+	Executions []WorkflowExecution
+}
+
+// ClosedWorkflows returns those of the given workflow executions that are no longer running.
+// Executions that can't be found, because their history has already been removed, are counted as closed.
+func (a *Activities) ClosedWorkflows(ctx context.Context, input *ClosedWorkflowsInput) (*ClosedWorkflowsResult, error) {
+	var result ClosedWorkflowsResult
+
+	if a.Client == nil {
+		return &result, errors.New("no Temporal client has been configured")
+	}
+
+	for _, execution := range input.Executions {
+		resp, err := a.Client.DescribeWorkflowExecution(ctx, execution.WorkflowID, execution.RunID)
+		var notFound *serviceerror.NotFound
+		if errors.As(err, &notFound) {
+			result.Executions = append(result.Executions, execution)
+			continue
+		}
+		if err != nil {
+			return &result, err
+		}
+
+		if resp.GetWorkflowExecutionInfo().GetStatus() != enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING {
+			result.Executions = append(result.Executions, execution)
+		}
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,37 @@
+package activities
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.temporal.io/sdk/temporal"
+)
+
+func TestVendorErrorRateLimited(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	recorder.Header().Set("Retry-After", "30")
+	recorder.WriteHeader(http.StatusTooManyRequests)
+
+	var applicationErr *temporal.ApplicationError
+	if !errors.As(vendorError(recorder.Result()), &applicationErr) {
+		t.Fatal("expected an application error")
+	}
+
+	// The workflow waits out the delay itself, so the activity must not retry.
+	assert.Equal(t, VendorErrorRateLimited, applicationErr.Type())
+	assert.True(t, applicationErr.NonRetryable())
+
+	var delay time.Duration
+	assert.NoError(t, applicationErr.Details(&delay))
+	assert.Equal(t, time.Second*30, delay)
+
+	// Without a Retry-After header the attempt is retried with the usual backoff.
+	recorder = httptest.NewRecorder()
+	recorder.WriteHeader(http.StatusTooManyRequests)
+	assert.True(t, errors.As(vendorError(recorder.Result()), &applicationErr))
+	assert.False(t, applicationErr.NonRetryable())
+}
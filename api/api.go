@@ -2,8 +2,11 @@ package api
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"strings"
@@ -11,11 +14,13 @@ import (
 	"github.com/gorilla/mux"
 
 	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
 	workflowpb "go.temporal.io/api/workflow/v1"
 	"go.temporal.io/api/workflowservice/v1"
 	"go.temporal.io/sdk/client"
 	"go.temporal.io/sdk/converter"
 
+	"github.com/temporalio/background-checks/activities"
 	"github.com/temporalio/background-checks/workflows"
 )
 
@@ -47,6 +52,22 @@ func getBackgroundCheckStatus(we *workflowpb.WorkflowExecutionInfo) (string, err
 	return status, err
 }
 
+func getBackgroundCheckSearchesPending(we *workflowpb.WorkflowExecutionInfo) (int, error) {
+	var pending int
+
+	attrs := we.GetSearchAttributes().GetIndexedFields()
+
+	// Checks that haven't started their searches yet won't have the attribute.
+	payload, ok := attrs["SearchesPending"]
+	if !ok {
+		return 0, nil
+	}
+
+	err := converter.GetDefaultDataConverter().FromPayload(payload, &pending)
+
+	return pending, err
+}
+
 func presentBackgroundCheck(we *workflowpb.WorkflowExecutionInfo) (BackgroundCheck, error) {
 	var result BackgroundCheck
 
@@ -63,6 +84,11 @@ func presentBackgroundCheck(we *workflowpb.WorkflowExecutionInfo) (BackgroundChe
 		return result, err
 	}
 
+	result.SearchesPending, err = getBackgroundCheckSearchesPending(we)
+	if err != nil {
+		return result, err
+	}
+
 	switch we.Status {
 	case enums.WORKFLOW_EXECUTION_STATUS_RUNNING:
 		result.Status = checkStatus
@@ -70,9 +96,10 @@ func presentBackgroundCheck(we *workflowpb.WorkflowExecutionInfo) (BackgroundChe
 		enums.WORKFLOW_EXECUTION_STATUS_FAILED:
 		result.Status = "failed"
 	case enums.WORKFLOW_EXECUTION_STATUS_COMPLETED:
-		if checkStatus == "declined" {
-			result.Status = "declined"
-		} else {
+		switch checkStatus {
+		case "declined", "expired", "adverse_action":
+			result.Status = checkStatus
+		default:
 			result.Status = "completed"
 		}
 	case enums.WORKFLOW_EXECUTION_STATUS_TERMINATED:
@@ -118,20 +145,16 @@ func candidateQuery(email string) string {
 
 func statusQuery(status string) (string, error) {
 	switch status {
-	case "pending_accept":
+	case "pending_accept", "running", "delayed", "pending_decision", "pre_adverse_action":
 		return fmt.Sprintf("ExecutionStatus = 'Running' AND BackgroundCheckStatus = '%s'", status), nil
-	case "running":
-		return fmt.Sprintf("ExecutionStatus = 'Running' AND BackgroundCheckStatus = '%s'", status), nil
-	case "completed":
-		return fmt.Sprintf("ExecutionStatus = 'Completed' AND BackgroundCheckStatus = '%s'", status), nil
-	case "declined":
+	case "completed", "declined", "expired", "adverse_action":
 		return fmt.Sprintf("ExecutionStatus = 'Completed' AND BackgroundCheckStatus = '%s'", status), nil
 	case "failed":
 		return "ExecutionStatus = 'Failed'", nil
 	case "terminated":
 		return "ExecutionStatus = 'Terminated'", nil
 	case "cancelled":
-		return "ExecutionStatus = 'Cancelled'", nil
+		return "ExecutionStatus = 'Canceled'", nil
 	default:
 		return "", fmt.Errorf("unknown status: %s", status)
 	}
@@ -201,6 +224,24 @@ func (h *handlers) handleCheckCreate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	_, err = workflows.LookupPackage(input.Tier)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Reminders and the notice to the Hiring Manager are only useful before the candidate's deadline.
+	for _, reminder := range input.AcceptReminders {
+		if reminder <= 0 || reminder >= workflows.AcceptGracePeriod {
+			http.Error(w, fmt.Sprintf("accept reminders must be within the %s grace period", workflows.AcceptGracePeriod), http.StatusBadRequest)
+			return
+		}
+	}
+	if input.UnresponsiveNoticePeriod < 0 || input.UnresponsiveNoticePeriod >= workflows.AcceptGracePeriod {
+		http.Error(w, fmt.Sprintf("unresponsive notice period must be within the %s grace period", workflows.AcceptGracePeriod), http.StatusBadRequest)
+		return
+	}
+
 	_, err = h.temporalClient.ExecuteWorkflow(
 		r.Context(),
 		client.StartWorkflowOptions{
@@ -223,6 +264,32 @@ func (h *handlers) handleCheckCreate(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusCreated)
 }
 
+func presentPackage(p workflows.SearchPackage) Package {
+	result := Package{
+		Name:        p.Name,
+		Description: p.Description,
+		Searches:    make([]string, len(p.Searches)),
+	}
+
+	for i, search := range p.Searches {
+		result.Searches[i] = search.Name
+	}
+
+	return result
+}
+
+func (h *handlers) handlePackageList(w http.ResponseWriter, r *http.Request) {
+	pkgs := workflows.Packages()
+
+	result := make([]Package, len(pkgs))
+	for i, p := range pkgs {
+		result[i] = presentPackage(p)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
 func (h *handlers) handleCheckStatus(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 
@@ -300,6 +367,13 @@ func (h *handlers) handleAccept(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	result.Accepted = true
+	result.CandidateDetails.Countries = workflows.NormalizeCountries(result.CandidateDetails.Countries)
+
+	err = workflows.ValidateReferences(result.CandidateDetails.References)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 
 	err = h.temporalClient.SignalWorkflow(
 		r.Context(),
@@ -341,6 +415,77 @@ func (h *handlers) handleDecline(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func (h *handlers) handleDispute(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	token := vars["token"]
+
+	wfid, runid, err := workflows.WorkflowFromToken(token)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var input workflows.DisputeSubmissionSignal
+
+	err = json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if input.SearchName == "" || input.Finding == "" {
+		http.Error(w, "a dispute must include the search and the finding being disputed", http.StatusBadRequest)
+		return
+	}
+
+	desc, err := h.temporalClient.DescribeWorkflowExecution(r.Context(), wfid, runid)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// A closed check would never see the dispute.
+	if desc.WorkflowExecutionInfo.Status != enums.WORKFLOW_EXECUTION_STATUS_RUNNING {
+		http.Error(w, "the background check has closed and can no longer be disputed", http.StatusConflict)
+		return
+	}
+
+	v, err := h.temporalClient.QueryWorkflow(
+		r.Context(),
+		wfid,
+		runid,
+		workflows.BackgroundCheckStatusQuery,
+	)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var check workflows.BackgroundCheckState
+	err = v.Get(&check)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	err = workflows.ValidateDispute(&check, input)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = h.temporalClient.SignalWorkflow(
+		r.Context(),
+		wfid,
+		runid,
+		workflows.DisputeSubmissionSignalName,
+		input,
+	)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
 func (h *handlers) handleEmploymentVerificationDetails(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	token := vars["token"]
@@ -356,8 +501,15 @@ func (h *handlers) handleEmploymentVerificationDetails(w http.ResponseWriter, r
 		wfid,
 		runid,
 		workflows.EmploymentVerificationDetailsQuery,
+		token,
 	)
 	if err != nil {
+		// The query fails if the verification has been reassigned or cancelled and this token revoked.
+		var queryFailed *serviceerror.QueryFailed
+		if errors.As(err, &queryFailed) {
+			http.Error(w, queryFailed.Message, http.StatusForbidden)
+			return
+		}
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -393,6 +545,7 @@ func (h *handlers) handleEmploymentVerificationSubmission(w http.ResponseWriter,
 	}
 
 	result := input
+	result.Token = token
 
 	err = h.temporalClient.SignalWorkflow(
 		r.Context(),
@@ -410,6 +563,363 @@ func (h *handlers) handleEmploymentVerificationSubmission(w http.ResponseWriter,
 	json.NewEncoder(w).Encode(result)
 }
 
+func (h *handlers) handleResearcherReviewDetails(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	token := vars["token"]
+
+	wfid, runid, err := workflows.WorkflowFromToken(token)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	enc, err := h.temporalClient.QueryWorkflow(
+		r.Context(),
+		wfid,
+		runid,
+		workflows.ResearcherReviewDetailsQuery,
+		token,
+	)
+	if err != nil {
+		// The query fails if the review has been reassigned or cancelled and this token revoked.
+		var queryFailed *serviceerror.QueryFailed
+		if errors.As(err, &queryFailed) {
+			http.Error(w, queryFailed.Message, http.StatusForbidden)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var result workflows.ResearcherReviewDetails
+	err = enc.Get(&result)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+func (h *handlers) handleResearcherReviewSubmission(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	token := vars["token"]
+
+	wfid, runid, err := workflows.WorkflowFromToken(token)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var input workflows.ResearcherReviewSubmissionSignal
+
+	err = json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	input.Token = token
+
+	err = h.temporalClient.SignalWorkflow(
+		r.Context(),
+		wfid,
+		runid,
+		workflows.ResearcherReviewSubmissionSignalName,
+		input,
+	)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// handleDrugScreenWebhook receives drug screen results from the lab. The token in the callback URL is the task token
+// of the AwaitDrugScreenResult activity that is waiting for the result, so we complete that activity with it.
+func (h *handlers) handleDrugScreenWebhook(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	taskToken, err := base64.URLEncoding.DecodeString(vars["token"])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var result activities.DrugScreenLabResult
+
+	err = json.NewDecoder(r.Body).Decode(&result)
+	if err != nil {
+		log.Println("Error: ", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = h.temporalClient.CompleteActivity(r.Context(), taskToken, &result, nil)
+	if err != nil {
+		// The drug screen is no longer waiting for this result, most likely because it passed its deadline.
+		var notFound *serviceerror.NotFound
+		if errors.As(err, &notFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *handlers) handleReferenceDetails(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	token := vars["token"]
+
+	wfid, runid, err := workflows.WorkflowFromToken(token)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	enc, err := h.temporalClient.QueryWorkflow(
+		r.Context(),
+		wfid,
+		runid,
+		workflows.ReferenceDetailsQuery,
+		token,
+	)
+	if err != nil {
+		// The query fails once the reference has responded or the deadline has passed.
+		var queryFailed *serviceerror.QueryFailed
+		if errors.As(err, &queryFailed) {
+			http.Error(w, queryFailed.Message, http.StatusForbidden)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var result workflows.ReferenceDetails
+	err = enc.Get(&result)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+func (h *handlers) handleReferenceResponse(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	token := vars["token"]
+
+	wfid, runid, err := workflows.WorkflowFromToken(token)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var input workflows.ReferenceResponseSignal
+
+	err = json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	input.Token = token
+
+	err = h.temporalClient.SignalWorkflow(
+		r.Context(),
+		wfid,
+		runid,
+		workflows.ReferenceResponseSignalName,
+		input,
+	)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *handlers) handleCheckDecision(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	email := vars["email"]
+
+	var input workflows.AdjudicationDecisionSignal
+
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if !workflows.ValidAdjudicationDecision(input.Decision) {
+		http.Error(w, fmt.Sprintf("unknown decision: %s", input.Decision), http.StatusBadRequest)
+		return
+	}
+
+	err = h.temporalClient.SignalWorkflow(
+		r.Context(),
+		workflows.BackgroundCheckWorkflowID(email),
+		"",
+		workflows.AdjudicationDecisionSignalName,
+		input,
+	)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *handlers) handleCheckUpgrade(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	email := vars["email"]
+
+	var input workflows.UpgradeSignal
+
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	v, err := h.temporalClient.QueryWorkflow(
+		r.Context(),
+		workflows.BackgroundCheckWorkflowID(email),
+		"",
+		workflows.BackgroundCheckStatusQuery,
+	)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var check workflows.BackgroundCheckState
+	err = v.Get(&check)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// The workflow ignores upgrades it can't apply, so reject them here where the Hiring Manager will see why.
+	err = workflows.ValidateUpgrade(&check, input.Tier)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = h.temporalClient.SignalWorkflow(
+		r.Context(),
+		workflows.BackgroundCheckWorkflowID(email),
+		"",
+		workflows.UpgradeSignalName,
+		input,
+	)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *handlers) handleMonitoringEnroll(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	email := vars["email"]
+
+	v, err := h.temporalClient.QueryWorkflow(
+		r.Context(),
+		workflows.BackgroundCheckWorkflowID(email),
+		"",
+		workflows.BackgroundCheckStatusQuery,
+	)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var check workflows.BackgroundCheckState
+	err = v.Get(&check)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if check.SSNTrace == nil || !check.SSNTrace.SSNIsValid {
+		http.Error(w, "candidate has no completed SSN trace to monitor", http.StatusBadRequest)
+		return
+	}
+
+	input := workflows.ContinuousMonitoringInputFromCheck(&check, workflows.DefaultMonitoringInterval)
+
+	_, err = h.temporalClient.ExecuteWorkflow(
+		r.Context(),
+		client.StartWorkflowOptions{
+			TaskQueue: TaskQueue,
+			ID:        workflows.ContinuousMonitoringWorkflowID(email),
+			SearchAttributes: map[string]interface{}{
+				"CandidateEmail": email,
+			},
+		},
+		workflows.ContinuousMonitoring,
+		&input,
+	)
+	if err != nil {
+		log.Printf("failed to start workflow: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (h *handlers) handleMonitoringStatus(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	email := vars["email"]
+
+	v, err := h.temporalClient.QueryWorkflow(
+		r.Context(),
+		workflows.ContinuousMonitoringWorkflowID(email),
+		"",
+		workflows.ContinuousMonitoringStatusQuery,
+	)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var result workflows.ContinuousMonitoringState
+	err = v.Get(&result)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+func (h *handlers) handleMonitoringStop(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	email := vars["email"]
+
+	err := h.temporalClient.SignalWorkflow(
+		r.Context(),
+		workflows.ContinuousMonitoringWorkflowID(email),
+		"",
+		workflows.ContinuousMonitoringStopSignalName,
+		nil,
+	)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
 func (h *handlers) handleCheckCancel(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 
@@ -417,7 +927,105 @@ func (h *handlers) handleCheckCancel(w http.ResponseWriter, r *http.Request) {
 	wfid := workflows.BackgroundCheckWorkflowID(email)
 	id := vars["id"]
 
-	err := h.temporalClient.CancelWorkflow(r.Context(), wfid, id)
+	// The body is optional. A check cancelled without saying who by is recorded as cancelled by CancelledByUnknown.
+	var input workflows.CancellationSignal
+
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Record who cancelled the check and why before cancelling it, so the workflow has the details when it cleans up.
+	err = h.temporalClient.SignalWorkflow(r.Context(), wfid, id, workflows.CancellationSignalName, input)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	err = h.temporalClient.CancelWorkflow(r.Context(), wfid, id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// EnsureResearcherPool starts the ResearcherPool workflow, seeded with the default researchers, if it isn't already running.
+func EnsureResearcherPool(ctx context.Context, c client.Client) error {
+	_, err := c.ExecuteWorkflow(
+		ctx,
+		client.StartWorkflowOptions{
+			TaskQueue: TaskQueue,
+			ID:        workflows.ResearcherPoolWorkflowID(),
+		},
+		workflows.ResearcherPool,
+		&workflows.ResearcherPoolWorkflowInput{Researchers: workflows.DefaultResearchers},
+	)
+	return err
+}
+
+func (h *handlers) handleResearcherList(w http.ResponseWriter, r *http.Request) {
+	v, err := h.temporalClient.QueryWorkflow(
+		r.Context(),
+		workflows.ResearcherPoolWorkflowID(),
+		"",
+		workflows.ResearcherPoolQuery,
+	)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var result workflows.ResearcherPoolState
+	err = v.Get(&result)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result.Researchers)
+}
+
+func (h *handlers) handleResearcherUpsert(w http.ResponseWriter, r *http.Request) {
+	var input workflows.Researcher
+
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if input.Email == "" {
+		http.Error(w, "a researcher must have an email address", http.StatusBadRequest)
+		return
+	}
+
+	err = h.temporalClient.SignalWorkflow(
+		r.Context(),
+		workflows.ResearcherPoolWorkflowID(),
+		"",
+		workflows.ResearcherUpsertSignalName,
+		input,
+	)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *handlers) handleResearcherRemove(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	email := vars["email"]
+
+	err := h.temporalClient.SignalWorkflow(
+		r.Context(),
+		workflows.ResearcherPoolWorkflowID(),
+		"",
+		workflows.ResearcherRemoveSignalName,
+		workflows.ResearcherRemoveSignal{Email: email},
+	)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -429,18 +1037,39 @@ func Router(c client.Client) *mux.Router {
 
 	h := handlers{temporalClient: c}
 
+	r.HandleFunc("/packages", h.handlePackageList).Methods("GET").Name("packages_list")
+
 	r.HandleFunc("/checks", h.handleCheckList).Methods("GET").Name("checks_list")
 	r.HandleFunc("/checks", h.handleCheckCreate).Methods("POST").Name("checks_create")
 	r.HandleFunc("/checks/{email}/{id}/cancel", h.handleCheckCancel).Methods("POST").Name("check_cancel")
+	r.HandleFunc("/checks/{email}/upgrade", h.handleCheckUpgrade).Methods("POST").Name("check_upgrade")
+	r.HandleFunc("/checks/{email}/decision", h.handleCheckDecision).Methods("POST").Name("check_decision")
+	r.HandleFunc("/checks/{email}/monitoring", h.handleMonitoringStatus).Methods("GET").Name("monitoring")
+	r.HandleFunc("/checks/{email}/monitoring", h.handleMonitoringEnroll).Methods("POST").Name("monitoring_enroll")
+	r.HandleFunc("/checks/{email}/monitoring/stop", h.handleMonitoringStop).Methods("POST").Name("monitoring_stop")
 	r.HandleFunc("/checks/{email}", h.handleCheckStatus).Methods("GET").Name("check")
 
 	r.HandleFunc("/checks/{token}/accept", h.handleAccept).Methods("POST").Name("accept")
 	r.HandleFunc("/checks/{token}/decline", h.handleDecline).Methods("POST").Name("decline")
 
+	r.HandleFunc("/checks/{token}/dispute", h.handleDispute).Methods("POST").Name("dispute")
+
 	r.HandleFunc("/checks/{token}/employment", h.handleEmploymentVerificationDetails).Methods("GET").Name("employmentverify_details")
 	r.HandleFunc("/checks/{token}/employment", h.handleEmploymentVerificationSubmission).Methods("POST").Name("employmentverify")
 
+	r.HandleFunc("/checks/{token}/review", h.handleResearcherReviewDetails).Methods("GET").Name("review_details")
+	r.HandleFunc("/checks/{token}/review", h.handleResearcherReviewSubmission).Methods("POST").Name("review")
+
+	r.HandleFunc("/checks/{token}/reference", h.handleReferenceDetails).Methods("GET").Name("reference_details")
+	r.HandleFunc("/checks/{token}/reference", h.handleReferenceResponse).Methods("POST").Name("reference")
+
 	r.HandleFunc("/checks/{token}/report", h.handleCheckReport).Methods("GET").Name("check_report")
 
+	r.HandleFunc("/webhooks/drugscreen/{token}", h.handleDrugScreenWebhook).Methods("POST").Name("drugscreen_webhook")
+
+	r.HandleFunc("/researchers", h.handleResearcherList).Methods("GET").Name("researchers_list")
+	r.HandleFunc("/researchers", h.handleResearcherUpsert).Methods("POST").Name("researchers_upsert")
+	r.HandleFunc("/researchers/{email}/remove", h.handleResearcherRemove).Methods("POST").Name("researchers_remove")
+
 	return r
 }
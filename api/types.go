@@ -4,4 +4,12 @@ type BackgroundCheck struct {
 	ID     string
 	Email  string
 	Status string
+	// SearchesPending is the number of searches that have not yet completed.
+	SearchesPending int
+}
+
+type Package struct {
+	Name        string
+	Description string
+	Searches    []string
 }
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"log"
 	"net/http"
 	"os"
@@ -23,6 +24,11 @@ var apiCmd = &cobra.Command{
 		}
 		defer c.Close()
 
+		err = api.EnsureResearcherPool(context.Background(), c)
+		if err != nil {
+			log.Fatalf("unable to start researcher pool: %v", err)
+		}
+
 		srv := &http.Server{
 			Handler: api.Router(c),
 			Addr:    "0.0.0.0:8081",
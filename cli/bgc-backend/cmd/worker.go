@@ -15,14 +15,27 @@ import (
 
 	"github.com/temporalio/background-checks/activities"
 	"github.com/temporalio/background-checks/temporal"
+	"github.com/temporalio/background-checks/watchlist"
 	"github.com/temporalio/background-checks/workflows"
 )
 
+var (
+	watchlistPath      string
+	vendorPoliciesPath string
+)
+
 // workerCmd represents the worker command
 var workerCmd = &cobra.Command{
 	Use:   "worker",
 	Short: "Run worker",
 	Run: func(cmd *cobra.Command, args []string) {
+		if vendorPoliciesPath != "" {
+			err := workflows.LoadVendorPolicies(vendorPoliciesPath)
+			if err != nil {
+				log.Fatalf("vendor policies error: %v", err)
+			}
+		}
+
 		c, err := temporal.NewClient(client.Options{
 			MetricsHandler: tallyhandler.NewMetricsHandler(newPrometheusScope(prometheus.Configuration{
 				ListenAddress: "0.0.0.0:8001",
@@ -39,11 +52,27 @@ var workerCmd = &cobra.Command{
 		w.RegisterWorkflow(workflows.BackgroundCheck)
 		w.RegisterWorkflow(workflows.Accept)
 		w.RegisterWorkflow(workflows.EmploymentVerification)
-		w.RegisterActivity(&activities.Activities{SMTPHost: "mailhog", SMTPPort: 1025})
+		w.RegisterActivity(&activities.Activities{SMTPHost: "mailhog", SMTPPort: 1025, Watchlist: watchlist.NewSource(watchlistPath), Client: c})
 		w.RegisterWorkflow(workflows.SSNTrace)
+		w.RegisterWorkflow(workflows.IdentityVerification)
 		w.RegisterWorkflow(workflows.FederalCriminalSearch)
 		w.RegisterWorkflow(workflows.StateCriminalSearch)
 		w.RegisterWorkflow(workflows.MotorVehicleIncidentSearch)
+		w.RegisterWorkflow(workflows.Dispute)
+		w.RegisterWorkflow(workflows.ContinuousMonitoring)
+		w.RegisterWorkflow(workflows.ResearcherPool)
+		w.RegisterWorkflow(workflows.ResearcherReview)
+		w.RegisterWorkflow(workflows.EducationVerification)
+		w.RegisterWorkflow(workflows.ProfessionalLicenseSearch)
+		w.RegisterWorkflow(workflows.DrugScreen)
+		w.RegisterWorkflow(workflows.SexOffenderRegistrySearch)
+		w.RegisterWorkflow(workflows.WatchlistSearch)
+		w.RegisterWorkflow(workflows.InternationalCriminalSearch)
+		w.RegisterWorkflow(workflows.CountryCriminalSearch)
+		w.RegisterWorkflow(workflows.CountyCriminalSearches)
+		w.RegisterWorkflow(workflows.CountyCriminalSearch)
+		w.RegisterWorkflow(workflows.ReferenceCheck)
+		w.RegisterWorkflow(workflows.CreditReportSearch)
 
 		err = w.Run(worker.InterruptCh())
 		if err != nil {
@@ -75,4 +104,6 @@ func newPrometheusScope(c prometheus.Configuration) tally.Scope {
 
 func init() {
 	rootCmd.AddCommand(workerCmd)
+	workerCmd.Flags().StringVar(&watchlistPath, "watchlist", "/etc/background-checks/watchlist/watchlist.json", "Sanctions and watch list file, reloaded when it changes")
+	workerCmd.Flags().StringVar(&vendorPoliciesPath, "vendor-policies", "", "JSON file of per-vendor timeouts and retry settings, overriding the defaults")
 }
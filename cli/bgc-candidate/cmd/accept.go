@@ -5,6 +5,7 @@ import (
 	"io"
 	"log"
 	"net/http"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/temporalio/background-checks/api"
@@ -25,12 +26,36 @@ var acceptCmd = &cobra.Command{
 			log.Fatalf("cannot create URL: %v", err)
 		}
 
+		education, err := parseEducation(Education)
+		if err != nil {
+			log.Fatalf("invalid education: %v", err)
+		}
+
+		licenses, err := parseLicenses(Licenses)
+		if err != nil {
+			log.Fatalf("invalid license: %v", err)
+		}
+
+		references, err := parseReferences(References)
+		if err != nil {
+			log.Fatalf("invalid reference: %v", err)
+		}
+
 		candidatedetails := workflows.CandidateDetails{
-			FullName: FullName,
-			SSN:      SSN,
-			Employer: Employer}
+			FullName:   FullName,
+			Aliases:    workflows.MergeAliases(FullName, Aliases, nil),
+			SSN:        SSN,
+			DOB:        DOB,
+			Address:    Address,
+			Employer:   Employer,
+			Education:  education,
+			Licenses:   licenses,
+			Countries:  workflows.NormalizeCountries(Countries),
+			References: references,
+		}
 		submission := workflows.AcceptSubmissionSignal{
 			CandidateDetails: candidatedetails,
+			CreditConsent:    CreditConsent,
 		}
 
 		response, err := utils.PostJSON(requestURL, submission)
@@ -49,15 +74,80 @@ var acceptCmd = &cobra.Command{
 	},
 }
 
+// parseEducation parses education entries given as "institution;degree;graduation date".
+func parseEducation(entries []string) ([]workflows.EducationRecord, error) {
+	var education []workflows.EducationRecord
+
+	for _, e := range entries {
+		parts := strings.Split(e, ";")
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("expected institution;degree;graduation date, got %q", e)
+		}
+		education = append(education, workflows.EducationRecord{
+			Institution:    strings.TrimSpace(parts[0]),
+			Degree:         strings.TrimSpace(parts[1]),
+			GraduationDate: strings.TrimSpace(parts[2]),
+		})
+	}
+
+	return education, nil
+}
+
+// parseLicenses parses professional licenses given as "type;number;state".
+func parseLicenses(entries []string) ([]workflows.LicenseRecord, error) {
+	var licenses []workflows.LicenseRecord
+
+	for _, e := range entries {
+		parts := strings.Split(e, ";")
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("expected type;number;state, got %q", e)
+		}
+		licenses = append(licenses, workflows.LicenseRecord{
+			Type:   strings.TrimSpace(parts[0]),
+			Number: strings.TrimSpace(parts[1]),
+			State:  strings.TrimSpace(parts[2]),
+		})
+	}
+
+	return licenses, nil
+}
+
+// parseReferences parses references given as "name;email;company;relationship".
+func parseReferences(entries []string) ([]workflows.Reference, error) {
+	var references []workflows.Reference
+
+	for _, e := range entries {
+		parts := strings.Split(e, ";")
+		if len(parts) != 4 {
+			return nil, fmt.Errorf("expected name;email;company;relationship, got %q", e)
+		}
+		references = append(references, workflows.Reference{
+			Name:         strings.TrimSpace(parts[0]),
+			Email:        strings.TrimSpace(parts[1]),
+			Company:      strings.TrimSpace(parts[2]),
+			Relationship: strings.TrimSpace(parts[3]),
+		})
+	}
+
+	return references, nil
+}
+
 func init() {
 	rootCmd.AddCommand(acceptCmd)
 	acceptCmd.Flags().StringVar(&Token, "token", "", "Token")
 	acceptCmd.MarkFlagRequired("token")
 	acceptCmd.Flags().StringVar(&FullName, "fullname", "", "Candidate's full name")
 	acceptCmd.MarkFlagRequired("fullname")
+	acceptCmd.Flags().StringArrayVar(&Aliases, "alias", nil, "Other name the candidate has been known by, such as a maiden name (may be repeated)")
 	acceptCmd.Flags().StringVar(&SSN, "ssn", "", "Social Security #")
 	acceptCmd.MarkFlagRequired("ssn")
+	acceptCmd.Flags().StringVar(&DOB, "dob", "", "Date of birth (YYYY-MM-DD)")
+	acceptCmd.Flags().StringVar(&Address, "address", "", "Current address as \"street, city, ST 12345\"")
 	acceptCmd.Flags().StringVar(&Employer, "employer", "", "Social Security #")
 	acceptCmd.MarkFlagRequired("employer")
-
+	acceptCmd.Flags().StringArrayVar(&Education, "education", nil, "Qualification as \"institution;degree;graduation date\" (may be repeated)")
+	acceptCmd.Flags().StringArrayVar(&Licenses, "license", nil, "Professional license as \"type;number;state\" (may be repeated)")
+	acceptCmd.Flags().StringArrayVar(&Countries, "country", nil, "Country lived in outside the US, as a two letter code (may be repeated)")
+	acceptCmd.Flags().BoolVar(&CreditConsent, "credit-consent", false, "Consent to an employment credit report")
+	acceptCmd.Flags().StringArrayVar(&References, "reference", nil, "Professional reference as \"name;email;company;relationship\" (may be repeated)")
 }
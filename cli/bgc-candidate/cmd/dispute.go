@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/spf13/cobra"
+	"github.com/temporalio/background-checks/api"
+	"github.com/temporalio/background-checks/utils"
+	"github.com/temporalio/background-checks/workflows"
+)
+
+// disputeCmd represents the dispute command
+var disputeCmd = &cobra.Command{
+	Use:   "dispute",
+	Short: "Dispute a finding in a background check report",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		router := api.Router(nil)
+
+		requestURL, err := router.Get("dispute").Host(APIEndpoint).URL("token", Token)
+		if err != nil {
+			log.Fatalf("cannot create URL: %v", err)
+		}
+
+		submission := workflows.DisputeSubmissionSignal{
+			SearchName: Search,
+			Finding:    Finding,
+			Reason:     Reason,
+		}
+
+		response, err := utils.PostJSON(requestURL, submission)
+		if err != nil {
+			log.Fatalf(err.Error())
+		}
+		defer response.Body.Close()
+
+		body, _ := io.ReadAll(response.Body)
+
+		if response.StatusCode != http.StatusOK {
+			log.Fatalf("%s: %s", http.StatusText(response.StatusCode), body)
+		}
+
+		fmt.Println("Dispute submitted")
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(disputeCmd)
+	disputeCmd.Flags().StringVar(&Token, "token", "", "Report token")
+	disputeCmd.MarkFlagRequired("token")
+	disputeCmd.Flags().StringVar(&Search, "search", "", "Search containing the disputed finding, e.g. StateCriminalSearch")
+	disputeCmd.MarkFlagRequired("search")
+	disputeCmd.Flags().StringVar(&Finding, "finding", "", "The finding being disputed")
+	disputeCmd.MarkFlagRequired("finding")
+	disputeCmd.Flags().StringVar(&Reason, "reason", "", "Why the finding is inaccurate or incomplete")
+}
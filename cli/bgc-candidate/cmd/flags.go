@@ -1,8 +1,19 @@
 package cmd
 
 var (
-	Token    string
-	FullName string
-	SSN      string
-	Employer string
+	Token         string
+	FullName      string
+	Aliases       []string
+	SSN           string
+	DOB           string
+	Address       string
+	Employer      string
+	Education     []string
+	Licenses      []string
+	Countries     []string
+	References    []string
+	CreditConsent bool
+	Search        string
+	Finding       string
+	Reason        string
 )
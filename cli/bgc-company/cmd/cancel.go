@@ -10,6 +10,7 @@ import (
 
 	"github.com/temporalio/background-checks/api"
 	"github.com/temporalio/background-checks/utils"
+	"github.com/temporalio/background-checks/workflows"
 )
 
 // cancelCmd represents the cancel command
@@ -25,7 +26,12 @@ var cancelCmd = &cobra.Command{
 			log.Fatalf("cannot create URL: %v", err)
 		}
 
-		response, err := utils.PostJSON(requestURL, nil)
+		input := workflows.CancellationSignal{
+			CancelledBy: by,
+			Reason:      reason,
+		}
+
+		response, err := utils.PostJSON(requestURL, input)
 		if err != nil {
 			log.Fatalf("request error: %v", err)
 		}
@@ -48,4 +54,6 @@ func init() {
 	cancelCmd.MarkFlagRequired("email")
 	cancelCmd.Flags().StringVar(&id, "id", "", "Check ID")
 	cancelCmd.MarkFlagRequired("id")
+	cancelCmd.Flags().StringVar(&by, "by", "", "Who is cancelling the check (default unknown)")
+	cancelCmd.Flags().StringVar(&reason, "reason", "", "Reason for cancelling the check")
 }
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/spf13/cobra"
+
+	"github.com/temporalio/background-checks/api"
+	"github.com/temporalio/background-checks/utils"
+	"github.com/temporalio/background-checks/workflows"
+)
+
+// decideCmd represents the decide command
+var decideCmd = &cobra.Command{
+	Use:   "decide",
+	Short: "records the hiring decision for a completed background check",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		router := api.Router(nil)
+
+		requestURL, err := router.Get("check_decision").Host(APIEndpoint).URL("email", email)
+		if err != nil {
+			log.Fatalf("cannot create URL: %v", err)
+		}
+
+		input := workflows.AdjudicationDecisionSignal{
+			Decision: decision,
+			Reason:   reason,
+		}
+
+		response, err := utils.PostJSON(requestURL, input)
+		if err != nil {
+			log.Fatalf("request error: %v", err)
+		}
+		defer response.Body.Close()
+
+		body, _ := io.ReadAll(response.Body)
+
+		if response.StatusCode != http.StatusOK {
+			log.Fatalf("%s: %s", http.StatusText(response.StatusCode), body)
+		}
+
+		fmt.Printf("Recorded decision\n")
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(decideCmd)
+
+	decideCmd.Flags().StringVar(&email, "email", "", "Candidate's email address")
+	decideCmd.MarkFlagRequired("email")
+	decideCmd.Flags().StringVar(&decision, "decision", "", "Hiring decision (proceed/adverse)")
+	decideCmd.MarkFlagRequired("decision")
+	decideCmd.Flags().StringVar(&reason, "reason", "", "Reason for the decision")
+}
@@ -1,8 +1,15 @@
 package cmd
 
+import "time"
+
 var (
-	id     string
-	email  string
-	pkg    string
-	status string
+	id                 string
+	email              string
+	pkg                string
+	status             string
+	decision           string
+	reason             string
+	by                 string
+	reminders          []time.Duration
+	unresponsiveNotice time.Duration
 )
@@ -35,6 +35,10 @@ var listCmd = &cobra.Command{
 
 		fmt.Printf("Background Checks:\n")
 		for _, check := range checks {
+			if check.SearchesPending > 0 {
+				fmt.Printf("ID: %s Email: %s Status: %s Searches Pending: %d\n", check.ID, check.Email, check.Status, check.SearchesPending)
+				continue
+			}
 			fmt.Printf("ID: %s Email: %s Status: %s\n", check.ID, check.Email, check.Status)
 		}
 	},
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/temporalio/background-checks/api"
+	"github.com/temporalio/background-checks/utils"
+	"github.com/temporalio/background-checks/workflows"
+)
+
+// monitorCmd represents the monitor command
+var monitorCmd = &cobra.Command{
+	Use:   "monitor",
+	Short: "manages continuous post-hire monitoring for an employee",
+}
+
+// monitorEnrollCmd represents the monitor enroll command
+var monitorEnrollCmd = &cobra.Command{
+	Use:   "enroll",
+	Short: "enrolls an employee in continuous monitoring using their completed background check",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		router := api.Router(nil)
+
+		requestURL, err := router.Get("monitoring_enroll").Host(APIEndpoint).URL("email", email)
+		if err != nil {
+			log.Fatalf("cannot create URL: %v", err)
+		}
+
+		response, err := utils.PostJSON(requestURL, nil)
+		if err != nil {
+			log.Fatalf("request error: %v", err)
+		}
+		defer response.Body.Close()
+
+		body, _ := io.ReadAll(response.Body)
+
+		if response.StatusCode != http.StatusCreated {
+			log.Fatalf("%s: %s", http.StatusText(response.StatusCode), body)
+		}
+
+		fmt.Printf("Enrolled in monitoring\n")
+	},
+}
+
+// monitorStopCmd represents the monitor stop command
+var monitorStopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "stops continuous monitoring for an employee",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		router := api.Router(nil)
+
+		requestURL, err := router.Get("monitoring_stop").Host(APIEndpoint).URL("email", email)
+		if err != nil {
+			log.Fatalf("cannot create URL: %v", err)
+		}
+
+		response, err := utils.PostJSON(requestURL, nil)
+		if err != nil {
+			log.Fatalf("request error: %v", err)
+		}
+		defer response.Body.Close()
+
+		body, _ := io.ReadAll(response.Body)
+
+		if response.StatusCode != http.StatusOK {
+			log.Fatalf("%s: %s", http.StatusText(response.StatusCode), body)
+		}
+
+		fmt.Printf("Stopped monitoring\n")
+	},
+}
+
+// monitorStatusCmd represents the monitor status command
+var monitorStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "shows the continuous monitoring status for an employee",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		router := api.Router(nil)
+
+		requestURL, err := router.Get("monitoring").Host(APIEndpoint).URL("email", email)
+		if err != nil {
+			log.Fatalf("cannot create URL: %v", err)
+		}
+
+		var state workflows.ContinuousMonitoringState
+		_, err = utils.GetJSON(requestURL, &state)
+		if err != nil {
+			log.Fatalf("request error: %v", err)
+		}
+
+		fmt.Printf("Email: %s Cycle: %d Last Run: %s Next Run: %s\n", state.Email, state.Cycle, state.LastRun, state.NextRun)
+		for name, findings := range state.NewFindings {
+			fmt.Printf("New findings in %s: %s\n", name, strings.Join(findings, ", "))
+		}
+		for name, missed := range state.Incomplete {
+			fmt.Printf("Incomplete %s: could not search %s\n", name, strings.Join(missed, ", "))
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(monitorCmd)
+	monitorCmd.AddCommand(monitorEnrollCmd)
+	monitorCmd.AddCommand(monitorStopCmd)
+	monitorCmd.AddCommand(monitorStatusCmd)
+
+	monitorCmd.PersistentFlags().StringVar(&email, "email", "", "Employee's email address")
+	monitorCmd.MarkPersistentFlagRequired("email")
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/temporalio/background-checks/api"
+	"github.com/temporalio/background-checks/utils"
+)
+
+// packagesCmd represents the packages command
+var packagesCmd = &cobra.Command{
+	Use:   "packages",
+	Short: "List the available check packages",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		router := api.Router(nil)
+
+		requestURL, err := router.Get("packages_list").Host(APIEndpoint).URL()
+		if err != nil {
+			log.Fatalf("cannot create URL: %v", err)
+		}
+
+		var pkgs []api.Package
+		_, err = utils.GetJSON(requestURL, &pkgs)
+		if err != nil {
+			log.Fatalf("request error: %v", err)
+		}
+
+		fmt.Printf("Packages:\n")
+		for _, p := range pkgs {
+			fmt.Printf("Name: %s Searches: %s\n", p.Name, strings.Join(p.Searches, ", "))
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(packagesCmd)
+}
@@ -27,8 +27,10 @@ var startCmd = &cobra.Command{
 		}
 
 		input := workflows.BackgroundCheckWorkflowInput{
-			Email: email,
-			Tier:  pkg,
+			Email:                    email,
+			Tier:                     pkg,
+			AcceptReminders:          reminders,
+			UnresponsiveNoticePeriod: unresponsiveNotice,
 		}
 
 		response, err := utils.PostJSON(requestURL, input)
@@ -52,5 +54,7 @@ func init() {
 
 	startCmd.Flags().StringVar(&email, "email", "", "Candidate's email address")
 	startCmd.MarkFlagRequired("email")
-	startCmd.Flags().StringVar(&pkg, "package", "standard", "Check package (standard/full)")
+	startCmd.Flags().StringVar(&pkg, "package", "standard", "Check package (see the packages command)")
+	startCmd.Flags().DurationSliceVar(&reminders, "reminders", nil, "Times after the request at which to remind the candidate to accept, such as 48h,120h (default 48h,120h)")
+	startCmd.Flags().DurationVar(&unresponsiveNotice, "unresponsive-notice", 0, "How long before the acceptance deadline to tell the Hiring Manager the candidate hasn't responded (default 24h)")
 }
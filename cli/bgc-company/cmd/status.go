@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/temporalio/background-checks/api"
+	"github.com/temporalio/background-checks/utils"
+	"github.com/temporalio/background-checks/workflows"
+)
+
+// formatSearchTime formats a search timestamp for display, or "-" if it hasn't happened yet.
+func formatSearchTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format(time.Stamp)
+}
+
+// statusCmd represents the status command
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "shows the progress of each search in a background check",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		router := api.Router(nil)
+
+		requestURL, err := router.Get("check").Host(APIEndpoint).URL("email", email)
+		if err != nil {
+			log.Fatalf("cannot create URL: %v", err)
+		}
+
+		var state workflows.BackgroundCheckState
+		_, err = utils.GetJSON(requestURL, &state)
+		if err != nil {
+			log.Fatalf("request error: %v", err)
+		}
+
+		fmt.Printf("Email: %s Package: %s\n", state.Email, state.Tier)
+		if state.Cancellation != nil {
+			fmt.Printf("Cancelled by %s at %s", state.Cancellation.CancelledBy, formatSearchTime(state.Cancellation.CancelledAt))
+			if state.Cancellation.Reason != "" {
+				fmt.Printf(": %s", state.Cancellation.Reason)
+			}
+			fmt.Printf("\n")
+		}
+		if state.Delayed {
+			fmt.Printf("This check has taken longer than its turnaround SLA\n")
+		}
+		if len(state.Searches) == 0 {
+			fmt.Printf("No searches have been started\n")
+			return
+		}
+
+		fmt.Printf("Searches:\n")
+		for _, search := range state.Searches {
+			fmt.Printf(
+				"%s: %s Scheduled: %s Started: %s Completed: %s\n",
+				search.Name, search.Status,
+				formatSearchTime(search.ScheduledAt), formatSearchTime(search.StartedAt), formatSearchTime(search.CompletedAt),
+			)
+			if e, ok := state.SearchErrors[search.Name]; ok {
+				fmt.Printf("  Error: %s: %s\n", e.Type, e.Message)
+			}
+		}
+		for name, reason := range state.RefusedSearches {
+			fmt.Printf("%s: not run (%s)\n", name, reason)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+
+	statusCmd.Flags().StringVar(&email, "email", "", "Candidate's email address")
+	statusCmd.MarkFlagRequired("email")
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/spf13/cobra"
+
+	"github.com/temporalio/background-checks/api"
+	"github.com/temporalio/background-checks/utils"
+	"github.com/temporalio/background-checks/workflows"
+)
+
+// upgradeCmd represents the upgrade command
+var upgradeCmd = &cobra.Command{
+	Use:   "upgrade",
+	Short: "upgrades a running background check to a different package",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		router := api.Router(nil)
+
+		requestURL, err := router.Get("check_upgrade").Host(APIEndpoint).URL("email", email)
+		if err != nil {
+			log.Fatalf("cannot create URL: %v", err)
+		}
+
+		input := workflows.UpgradeSignal{
+			Tier: pkg,
+		}
+
+		response, err := utils.PostJSON(requestURL, input)
+		if err != nil {
+			log.Fatalf("request error: %v", err)
+		}
+		defer response.Body.Close()
+
+		body, _ := io.ReadAll(response.Body)
+
+		if response.StatusCode != http.StatusOK {
+			log.Fatalf("%s: %s", http.StatusText(response.StatusCode), body)
+		}
+
+		fmt.Printf("Upgraded check\n")
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(upgradeCmd)
+
+	upgradeCmd.Flags().StringVar(&email, "email", "", "Candidate's email address")
+	upgradeCmd.MarkFlagRequired("email")
+	upgradeCmd.Flags().StringVar(&pkg, "package", "", "Check package to upgrade to (see the packages command)")
+	upgradeCmd.MarkFlagRequired("package")
+}
@@ -1,5 +1,12 @@
 package cmd
 
 var (
-	Token string
+	Token         string
+	Email         string
+	Skills        []string
+	Jurisdictions []string
+	Capacity      int
+	Unavailable   bool
+	Reject        bool
+	Notes         string
 )
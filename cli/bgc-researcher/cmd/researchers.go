@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/temporalio/background-checks/api"
+	"github.com/temporalio/background-checks/utils"
+	"github.com/temporalio/background-checks/workflows"
+)
+
+// researchersCmd represents the researchers command
+var researchersCmd = &cobra.Command{
+	Use:   "researchers",
+	Short: "Manage the researcher pool",
+}
+
+// researchersListCmd represents the researchers list command
+var researchersListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List researchers and their open assignments",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		router := api.Router(nil)
+
+		requestURL, err := router.Get("researchers_list").Host(APIEndpoint).URL()
+		if err != nil {
+			log.Fatalf("cannot create URL: %v", err)
+		}
+
+		var researchers []workflows.Researcher
+		_, err = utils.GetJSON(requestURL, &researchers)
+		if err != nil {
+			log.Fatalf("request error: %v", err)
+		}
+
+		for _, r := range researchers {
+			jurisdictions := "all"
+			if len(r.Jurisdictions) > 0 {
+				jurisdictions = strings.Join(r.Jurisdictions, ",")
+			}
+			fmt.Printf(
+				"Email: %s Available: %t Assignments: %d/%d Skills: %s Jurisdictions: %s\n",
+				r.Email, r.Available, len(r.OpenAssignments), r.Capacity, strings.Join(r.Skills, ","), jurisdictions,
+			)
+		}
+	},
+}
+
+// researchersSetCmd represents the researchers set command
+var researchersSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Add a researcher to the pool, or update their details",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		router := api.Router(nil)
+
+		requestURL, err := router.Get("researchers_upsert").Host(APIEndpoint).URL()
+		if err != nil {
+			log.Fatalf("cannot create URL: %v", err)
+		}
+
+		researcher := workflows.Researcher{
+			Email:         Email,
+			Skills:        Skills,
+			Jurisdictions: Jurisdictions,
+			Capacity:      Capacity,
+			Available:     !Unavailable,
+		}
+
+		response, err := utils.PostJSON(requestURL, researcher)
+		if err != nil {
+			log.Fatalf("request error: %v", err)
+		}
+		defer response.Body.Close()
+
+		body, _ := io.ReadAll(response.Body)
+
+		if response.StatusCode != http.StatusOK {
+			log.Fatalf("%s: %s", http.StatusText(response.StatusCode), body)
+		}
+
+		fmt.Println("Researcher updated")
+	},
+}
+
+// researchersRemoveCmd represents the researchers remove command
+var researchersRemoveCmd = &cobra.Command{
+	Use:   "remove",
+	Short: "Remove a researcher from the pool",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		router := api.Router(nil)
+
+		requestURL, err := router.Get("researchers_remove").Host(APIEndpoint).URL("email", Email)
+		if err != nil {
+			log.Fatalf("cannot create URL: %v", err)
+		}
+
+		response, err := utils.PostJSON(requestURL, nil)
+		if err != nil {
+			log.Fatalf("request error: %v", err)
+		}
+		defer response.Body.Close()
+
+		body, _ := io.ReadAll(response.Body)
+
+		if response.StatusCode != http.StatusOK {
+			log.Fatalf("%s: %s", http.StatusText(response.StatusCode), body)
+		}
+
+		fmt.Println("Researcher removed")
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(researchersCmd)
+	researchersCmd.AddCommand(researchersListCmd)
+	researchersCmd.AddCommand(researchersSetCmd)
+	researchersCmd.AddCommand(researchersRemoveCmd)
+
+	researchersSetCmd.Flags().StringVar(&Email, "email", "", "Researcher's email address")
+	researchersSetCmd.MarkFlagRequired("email")
+	researchersSetCmd.Flags().StringSliceVar(&Skills, "skill", []string{workflows.ResearcherSkillEmployment}, "Kind of research the researcher can be assigned")
+	researchersSetCmd.Flags().StringSliceVar(&Jurisdictions, "jurisdiction", nil, "State the researcher covers (default all)")
+	researchersSetCmd.Flags().IntVar(&Capacity, "capacity", workflows.DefaultResearcherCapacity, "Number of assignments the researcher can have open at once")
+	researchersSetCmd.Flags().BoolVar(&Unavailable, "unavailable", false, "Stop assigning new work to the researcher")
+
+	researchersRemoveCmd.Flags().StringVar(&Email, "email", "", "Researcher's email address")
+	researchersRemoveCmd.MarkFlagRequired("email")
+}
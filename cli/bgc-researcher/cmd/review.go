@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/spf13/cobra"
+	"github.com/temporalio/background-checks/api"
+	"github.com/temporalio/background-checks/utils"
+	"github.com/temporalio/background-checks/workflows"
+)
+
+var reviewCmd = &cobra.Command{
+	Use:   "review",
+	Short: "Complete a review that was assigned to you",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		router := api.Router(nil)
+
+		detailsURL, err := router.Get("review_details").Host(APIEndpoint).URL("token", Token)
+		if err != nil {
+			log.Fatalf("cannot create URL: %v", err)
+		}
+
+		var review workflows.ResearcherReviewDetails
+		_, err = utils.GetJSON(detailsURL, &review)
+		if err != nil {
+			log.Fatalf("request error: %v", err)
+		}
+
+		fmt.Printf("%s: %s\n", review.Subject, review.Question)
+
+		requestURL, err := router.Get("review").Host(APIEndpoint).URL("token", Token)
+		if err != nil {
+			log.Fatalf("cannot create URL: %v", err)
+		}
+
+		submission := workflows.ResearcherReviewSubmissionSignal{
+			Confirmed: !Reject,
+			Notes:     Notes,
+		}
+
+		response, err := utils.PostJSON(requestURL, submission)
+		if err != nil {
+			log.Fatalln(err.Error())
+		}
+		defer response.Body.Close()
+
+		body, _ := io.ReadAll(response.Body)
+
+		if response.StatusCode != http.StatusOK {
+			log.Fatalf("%s: %s", http.StatusText(response.StatusCode), body)
+		}
+		fmt.Println("Review received")
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(reviewCmd)
+	reviewCmd.Flags().StringVar(&Token, "token", "", "Token")
+	reviewCmd.MarkFlagRequired("token")
+	reviewCmd.Flags().BoolVar(&Reject, "reject", false, "Report that the details could not be confirmed")
+	reviewCmd.Flags().StringVar(&Notes, "notes", "", "Notes on your findings")
+}
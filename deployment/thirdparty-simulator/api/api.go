@@ -2,12 +2,16 @@ package api
 
 import (
 	"encoding/json"
+	"fmt"
 	"log"
 	"math/rand"
 	"net/http"
 	"os"
 	"os/signal"
 	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/github/go-fault"
 	"github.com/gorilla/mux"
@@ -23,9 +27,35 @@ type SSNTraceInput struct {
 	SSN      string
 }
 
+// SSNTraceAddress is an address linked to an SSN, with the dates it was in use.
+// To is blank for the current address.
+type SSNTraceAddress struct {
+	Address string
+	From    string
+	To      string
+}
+
 type SSNTraceResult struct {
 	SSNIsValid     bool
-	KnownAddresses []string
+	KnownAddresses []SSNTraceAddress
+	NamesOnRecord  []string
+	DOBOnRecord    string
+	IssuedYear     int
+}
+
+type ssnRecord struct {
+	Names      []string
+	DOB        string
+	IssuedYear int
+}
+
+// ssnRecords is the identity the SSN administration holds for each of our test SSNs.
+// 333-33-3333 was issued before its holder's date of birth, which should be flagged.
+var ssnRecords = map[string]ssnRecord{
+	"111-11-1111": {Names: []string{"John Smith"}, DOB: "1981-07-22", IssuedYear: 1981},
+	"222-22-2222": {Names: []string{"Jane Doe", "Jane Roe"}, DOB: "1985-02-11", IssuedYear: 1999},
+	"333-33-3333": {Names: []string{"Alex Garcia"}, DOB: "1985-03-14", IssuedYear: 1979},
+	"444-44-4444": {Names: []string{"Sam Taylor"}, DOB: "1990-06-30", IssuedYear: 1990},
 }
 
 type MotorVehicleIncidentSearchInput struct {
@@ -58,6 +88,198 @@ type StateCriminalSearchResult struct {
 	Crimes   []string
 }
 
+type EducationVerificationInput struct {
+	FullName    string
+	Institution string
+}
+
+type EducationVerificationResult struct {
+	RecordFound    bool
+	Degree         string
+	GraduationDate string
+}
+
+type educationRecord struct {
+	FullName       string
+	Institution    string
+	Degree         string
+	GraduationDate string
+}
+
+// educationRecords is the fixed set of records the education vendor knows about.
+// Anything else is reported as not found, so that it goes to a researcher.
+var educationRecords = []educationRecord{
+	{FullName: "John Smith", Institution: "State University", Degree: "BSc Computer Science", GraduationDate: "2003-05"},
+	{FullName: "John Smith", Institution: "City College", Degree: "MBA", GraduationDate: "2008-06"},
+	{FullName: "Jane Doe", Institution: "State University", Degree: "BA English", GraduationDate: "2010-05"},
+	{FullName: "Jane Doe", Institution: "Technical Institute", Degree: "MSc Data Science", GraduationDate: "2012-12"},
+	{FullName: "Jane Roe", Institution: "City College", Degree: "BA History", GraduationDate: "2006-05"},
+	{FullName: "Alex Garcia", Institution: "City College", Degree: "BSc Nursing", GraduationDate: "2015-06"},
+}
+
+type ProfessionalLicenseSearchInput struct {
+	FullName string
+	Type     string
+	Number   string
+	State    string
+}
+
+type ProfessionalLicenseSearchResult struct {
+	Found     bool
+	Status    string
+	Expires   string
+	Sanctions []string
+}
+
+type licenseRecord struct {
+	Type      string
+	Number    string
+	State     string
+	Status    string
+	Expires   string
+	Sanctions []string
+}
+
+// licenseRecords is the fixed set of licenses the licensing boards know about.
+var licenseRecords = []licenseRecord{
+	{Type: "RN", Number: "123456", State: "NY", Status: "active", Expires: "2030-06-30"},
+	{Type: "RN", Number: "654321", State: "CA", Status: "active", Expires: "2022-01-31"},
+	{Type: "RN", Number: "111222", State: "IL", Status: "suspended", Expires: "2029-03-31", Sanctions: []string{"Suspension for practicing outside scope, 2023"}},
+	{Type: "CPA", Number: "998877", State: "IL", Status: "active", Expires: "2030-12-31"},
+	{Type: "CPA", Number: "887766", State: "NY", Status: "active", Expires: "2030-12-31", Sanctions: []string{"Public reprimand, 2019"}},
+	{Type: "Series 7", Number: "7654321", State: "NY", Status: "active", Expires: "2031-01-01"},
+	{Type: "Series 7", Number: "1234567", State: "MA", Status: "lapsed", Expires: "2020-01-01"},
+}
+
+type CountyCriminalSearchInput struct {
+	FullName string
+	County   string
+	State    string
+	From     string
+	To       string
+}
+
+type CountyCriminalSearchResult struct {
+	Crimes []string
+}
+
+type CreditReportInput struct {
+	FullName string
+	SSN      string
+	DOB      string
+}
+
+type TradeLine struct {
+	Creditor string
+	Type     string
+	Opened   string
+	Balance  int
+	Limit    int
+	Status   string
+}
+
+type Collection struct {
+	Agency           string
+	OriginalCreditor string
+	Amount           int
+	Reported         string
+}
+
+type Bankruptcy struct {
+	Chapter int
+	Court   string
+	Filed   string
+	Status  string
+}
+
+type CreditReportResult struct {
+	TradeLines   []TradeLine
+	Collections  []Collection
+	Bankruptcies []Bankruptcy
+}
+
+// creditFiles is the fixed set of credit files the bureau holds, keyed by SSN.
+// Anyone else gets a thin file with a single credit card.
+var creditFiles = map[string]CreditReportResult{
+	"111-11-1111": {
+		TradeLines: []TradeLine{
+			{Creditor: "First National Bank", Type: "mortgage", Opened: "2012-04", Balance: 182000, Limit: 250000, Status: "current"},
+			{Creditor: "Metro Credit Union", Type: "auto", Opened: "2019-09", Balance: 8400, Limit: 22000, Status: "current"},
+			{Creditor: "Acme Card Services", Type: "revolving", Opened: "2008-01", Balance: 1200, Limit: 10000, Status: "current"},
+		},
+	},
+	"222-22-2222": {
+		TradeLines: []TradeLine{
+			{Creditor: "Acme Card Services", Type: "revolving", Opened: "2015-06", Balance: 4900, Limit: 5000, Status: "60 days late"},
+			{Creditor: "QuickLoan", Type: "installment", Opened: "2020-02", Balance: 0, Limit: 3000, Status: "charged off"},
+		},
+		Collections: []Collection{
+			{Agency: "Recovery Partners", OriginalCreditor: "QuickLoan", Amount: 2750, Reported: "2021-08"},
+			{Agency: "Medical Debt Services", OriginalCreditor: "County Hospital", Amount: 640, Reported: "2022-03"},
+		},
+		Bankruptcies: []Bankruptcy{
+			{Chapter: 7, Court: "US Bankruptcy Court, N.D. Ill.", Filed: "2016-11-03", Status: "discharged"},
+		},
+	},
+}
+
+type SexOffenderRegistryInput struct {
+	FullName string
+	DOB      string
+}
+
+type SexOffenderRegistryMatch struct {
+	Name       string
+	DOB        string
+	State      string
+	Offense    string
+	Confidence float64
+}
+
+type SexOffenderRegistryResult struct {
+	Matches []SexOffenderRegistryMatch
+}
+
+type sexOffenderRecord struct {
+	Name    string
+	DOB     string
+	State   string
+	Offense string
+}
+
+// sexOffenderRegistry is the fixed set of registrants the national registry knows about.
+var sexOffenderRegistry = []sexOffenderRecord{
+	{Name: "Alex Garcia", DOB: "1985-03-14", State: "TX", Offense: "Indecent exposure, 2009"},
+	{Name: "John Smith", DOB: "1981-07-22", State: "FL", Offense: "Possession of prohibited material, 2012"},
+	{Name: "J. Doe", DOB: "1990-11-02", State: "OH", Offense: "Solicitation of a minor, 2015"},
+}
+
+// registryMatchConfidence scores how likely a registry entry is to be the person searched for.
+// A score of 0 means the entry is not a match at all.
+func registryMatchConfidence(record sexOffenderRecord, input SexOffenderRegistryInput) float64 {
+	sameName := strings.EqualFold(record.Name, input.FullName)
+	sameDOB := record.DOB == input.DOB
+	sameYear := len(record.DOB) >= 4 && len(input.DOB) >= 4 && record.DOB[:4] == input.DOB[:4]
+
+	names := strings.Fields(input.FullName)
+	sameInitial := false
+	if len(names) > 1 {
+		initial := fmt.Sprintf("%c. %s", names[0][0], names[len(names)-1])
+		sameInitial = strings.EqualFold(record.Name, initial)
+	}
+
+	switch {
+	case sameName && sameDOB:
+		return 0.98
+	case sameInitial && sameDOB:
+		return 0.75
+	case sameName && sameYear:
+		return 0.6
+	default:
+		return 0
+	}
+}
+
 func handleSsnTrace(w http.ResponseWriter, r *http.Request) {
 	var input SSNTraceInput
 
@@ -72,14 +294,38 @@ func handleSsnTrace(w http.ResponseWriter, r *http.Request) {
 	var validSSN = regexp.MustCompile(`\d{3}-\d{2}-\d{4}$`)
 	result.SSNIsValid = validSSN.MatchString(input.SSN)
 
-	addressMap := map[string][]string{
-		"111-11-1111": {"123 Broadway, New York, NY 10011", "1 E. 161 St, Bronx, NY 10451", "41 Seaver Way, Queens, NY 11368"},
-		"222-22-2222": {"456 Oak Street, Springfield, IL 62706", "1060 W. Addison St, Chicago, IL 60613"},
-		"333-33-3333": {"4 Jersey St, Boston, MA 02215", "333 W Camden St, Baltimore, MD 21201"},
-		"444-44-4444": {"1 Royal Way, Kansas City, MO 64129", "", "700 Clark Ave, St Louis, MO 63102"}}
+	// Like real trace data, the history has gaps, duplicates and junk entries in it.
+	addressMap := map[string][]SSNTraceAddress{
+		"111-11-1111": {
+			{Address: "123 Broadway, New York, NY 10011", From: "2019-03-01"},
+			{Address: "1 E. 161 St, Bronx, NY 10451", From: "2014-06-01", To: "2019-02-28"},
+			{Address: "41 Seaver Way, Queens, NY 11368", From: "2009-09-01", To: "2014-05-31"},
+		},
+		"222-22-2222": {
+			{Address: "456 Oak Street, Springfield, IL 62706", From: "2017-01-01"},
+			{Address: "1060 W. Addison St, Chicago, IL 60613", From: "2012-08-01", To: "2016-12-31"},
+			{Address: "1060 W Addison St,  Chicago, IL 60613", From: "2011-05-01", To: "2012-07-31"},
+		},
+		"333-33-3333": {
+			{Address: "4 Jersey St, Boston, MA 02215", From: "2016-04-01"},
+			{Address: "333 W Camden St, Baltimore, MD 21201", From: "2010-01-01", To: "2016-03-31"},
+		},
+		"444-44-4444": {
+			{Address: "1 Royal Way, Kansas City, MO 64129", From: "2018-07-01"},
+			{Address: "", From: "2016-01-01", To: "2018-06-30"},
+			{Address: "700 Clark Ave, St Louis, MO 63102", From: "2015-01-01", To: "2012-12-31"},
+			{Address: "700 Clark Ave, St Louis, MO 63102", From: "2011-01-01", To: "2015-12-31"},
+		},
+	}
 
 	result.KnownAddresses = addressMap[input.SSN]
 
+	if record, ok := ssnRecords[input.SSN]; ok {
+		result.NamesOnRecord = record.Names
+		result.DOBOnRecord = record.DOB
+		result.IssuedYear = record.IssuedYear
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(result)
 }
@@ -177,18 +423,193 @@ func handleStateCriminalSearch(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(result)
 }
 
-func Router() *mux.Router {
+func handleEducationVerification(w http.ResponseWriter, r *http.Request) {
+	var input EducationVerificationInput
+
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var result EducationVerificationResult
+
+	for _, record := range educationRecords {
+		if strings.EqualFold(record.FullName, input.FullName) && strings.EqualFold(record.Institution, input.Institution) {
+			result.RecordFound = true
+			result.Degree = record.Degree
+			result.GraduationDate = record.GraduationDate
+			break
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+func handleProfessionalLicenseSearch(w http.ResponseWriter, r *http.Request) {
+	var input ProfessionalLicenseSearchInput
+
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var result ProfessionalLicenseSearchResult
+
+	for _, record := range licenseRecords {
+		if strings.EqualFold(record.Type, input.Type) && record.Number == input.Number && strings.EqualFold(record.State, input.State) {
+			result.Found = true
+			result.Status = record.Status
+			result.Expires = record.Expires
+			result.Sanctions = record.Sanctions
+			break
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// Options configures the behaviour of the simulator.
+type Options struct {
+	// LabResultDelay is how long the drug screen lab takes to post results back.
+	LabResultDelay time.Duration
+	// InternationalSearchLatency is how long international criminal searches take, before each country's factor.
+	InternationalSearchLatency time.Duration
+}
+
+func handleCountyCriminalSearch(w http.ResponseWriter, r *http.Request) {
+	var input CountyCriminalSearchInput
+
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	from, err := time.Parse("2006-01-02", input.From)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	to, err := time.Parse("2006-01-02", input.To)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var result CountyCriminalSearchResult
+
+	possibleCrimes := []string{
+		"Disorderly Conduct",
+		"Petty Theft",
+		"Vandalism",
+		"Trespassing",
+	}
+
+	// Only records filed within the requested window are returned.
+	rndnum := rand.Intn(100)
+	if rndnum > 75 {
+		filed := to.AddDate(0, 0, -rand.Intn(365*10))
+		if !filed.Before(from) {
+			crime := possibleCrimes[rand.Intn(len(possibleCrimes))]
+			result.Crimes = append(result.Crimes, fmt.Sprintf("%s, %d", crime, filed.Year()))
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+func handleSexOffenderRegistry(w http.ResponseWriter, r *http.Request) {
+	var input SexOffenderRegistryInput
+
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var result SexOffenderRegistryResult
+
+	for _, record := range sexOffenderRegistry {
+		confidence := registryMatchConfidence(record, input)
+		if confidence == 0 {
+			continue
+		}
+		result.Matches = append(result.Matches, SexOffenderRegistryMatch{
+			Name:       record.Name,
+			DOB:        record.DOB,
+			State:      record.State,
+			Offense:    record.Offense,
+			Confidence: confidence,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+func handleCreditReport(w http.ResponseWriter, r *http.Request) {
+	var input CreditReportInput
+
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, ok := creditFiles[input.SSN]
+	if !ok {
+		result = CreditReportResult{
+			TradeLines: []TradeLine{
+				{Creditor: "Acme Card Services", Type: "revolving", Opened: "2018-03", Balance: 300, Limit: 2000, Status: "current"},
+			},
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+func Router(options Options) *mux.Router {
 	r := mux.NewRouter()
+	l := newLab(options.LabResultDelay)
+	v := newInternationalVendor(options.InternationalSearchLatency)
 
 	r.HandleFunc("/ssntrace", handleSsnTrace).Methods("POST")
 	r.HandleFunc("/motorvehiclesearch", handleMotorVehicleSearch).Methods("POST")
 	r.HandleFunc("/federalcriminalsearch", handleFederalCriminalSearch).Methods("POST")
 	r.HandleFunc("/statecriminalsearch", handleStateCriminalSearch).Methods("POST")
+	r.HandleFunc("/educationverification", handleEducationVerification).Methods("POST")
+	r.HandleFunc("/professionallicensesearch", handleProfessionalLicenseSearch).Methods("POST")
+	r.HandleFunc("/countycriminalsearch", handleCountyCriminalSearch).Methods("POST")
+	r.HandleFunc("/sexoffenderregistry", handleSexOffenderRegistry).Methods("POST")
+	r.HandleFunc("/creditreport", handleCreditReport).Methods("POST")
+	r.HandleFunc("/internationalcriminalsearch/{country}", v.handleSearch).Methods("POST")
+	r.HandleFunc("/drugscreen/orders", l.handleOrder).Methods("POST")
+	r.HandleFunc("/drugscreen/orders/{id}/callback", l.handleCallback).Methods("POST")
+	r.HandleFunc("/drugscreen/appointments/{id}", l.handleAppointment).Methods("GET")
 
 	return r
 }
 
-func Run() {
+// rateLimitFault makes a share of requests fail with 429 Too Many Requests and a Retry-After header, as real vendors
+// do when they are sent too many requests at once.
+func rateLimitFault(participation float64, retryAfter time.Duration, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if rand.Float64() < participation {
+			w.Header().Set("Retry-After", strconv.Itoa(int(retryAfter.Seconds())))
+			http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func Run(options Options) {
 	var err error
 
 	errorInjector, _ := fault.NewErrorInjector(http.StatusInternalServerError)
@@ -197,7 +618,7 @@ func Run() {
 		fault.WithParticipation(0.3),
 	)
 
-	handlerChain := errorFault.Handler(Router())
+	handlerChain := rateLimitFault(0.05, time.Second*2, errorFault.Handler(Router(options)))
 
 	srv := &http.Server{
 		Handler: handlerChain,
@@ -0,0 +1,176 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// DefaultLabResultDelay is how long the lab takes to post a drug screen result back once it has been ordered.
+const DefaultLabResultDelay = time.Minute
+
+type DrugScreenOrderInput struct {
+	FullName string
+	Email    string
+}
+
+type DrugScreenOrderResult struct {
+	OrderID        string
+	AppointmentURL string
+}
+
+type DrugScreenCallbackInput struct {
+	CallbackURL string
+}
+
+type DrugScreenResult struct {
+	OrderID    string
+	Result     string
+	Substances []string
+}
+
+// drugScreenFindings is the fixed set of candidates whose drug screens come back positive.
+// Everyone else tests negative.
+var drugScreenFindings = map[string][]string{
+	"Alex Garcia": {"THC"},
+}
+
+type drugScreenOrder struct {
+	FullName    string
+	CallbackURL string
+	Result      *DrugScreenResult
+}
+
+// lab simulates a drug testing lab. Results are posted to the order's callback URL once the result delay has passed,
+// or as soon as a callback is registered if the result is already available.
+type lab struct {
+	sync.Mutex
+	resultDelay time.Duration
+	nextID      int
+	orders      map[string]*drugScreenOrder
+}
+
+func newLab(resultDelay time.Duration) *lab {
+	return &lab{resultDelay: resultDelay, orders: make(map[string]*drugScreenOrder)}
+}
+
+func (l *lab) order(fullName string) string {
+	l.Lock()
+	defer l.Unlock()
+
+	l.nextID++
+	id := strconv.Itoa(l.nextID)
+	l.orders[id] = &drugScreenOrder{FullName: fullName}
+
+	time.AfterFunc(l.resultDelay, func() { l.complete(id) })
+
+	return id
+}
+
+func (l *lab) complete(id string) {
+	l.Lock()
+	defer l.Unlock()
+
+	order := l.orders[id]
+	order.Result = &DrugScreenResult{OrderID: id, Result: "negative"}
+	for name, substances := range drugScreenFindings {
+		if strings.EqualFold(name, order.FullName) {
+			order.Result.Result = "positive"
+			order.Result.Substances = substances
+		}
+	}
+
+	if order.CallbackURL != "" {
+		go postDrugScreenResult(order.CallbackURL, *order.Result)
+	}
+}
+
+func (l *lab) registerCallback(id string, url string) bool {
+	l.Lock()
+	defer l.Unlock()
+
+	order, ok := l.orders[id]
+	if !ok {
+		return false
+	}
+
+	order.CallbackURL = url
+	if order.Result != nil {
+		go postDrugScreenResult(order.CallbackURL, *order.Result)
+	}
+
+	return true
+}
+
+// postDrugScreenResult sends a result to a callback URL, retrying a few times if the receiver is unavailable.
+func postDrugScreenResult(url string, result DrugScreenResult) {
+	body, _ := json.Marshal(result)
+
+	for attempt := 1; attempt <= 5; attempt++ {
+		r, err := http.Post(url, "application/json", bytes.NewReader(body))
+		if err == nil {
+			r.Body.Close()
+			if r.StatusCode < 500 {
+				log.Printf("drug screen result for order %s delivered: %s", result.OrderID, r.Status)
+				return
+			}
+			err = fmt.Errorf("%s", r.Status)
+		}
+
+		log.Printf("failed to deliver drug screen result for order %s: %v", result.OrderID, err)
+		time.Sleep(time.Duration(attempt) * time.Second * 5)
+	}
+}
+
+func (l *lab) handleOrder(w http.ResponseWriter, r *http.Request) {
+	var input DrugScreenOrderInput
+
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	id := l.order(input.FullName)
+
+	result := DrugScreenOrderResult{
+		OrderID:        id,
+		AppointmentURL: fmt.Sprintf("http://localhost:8082/drugscreen/appointments/%s", id),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+func (l *lab) handleCallback(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var input DrugScreenCallbackInput
+
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if !l.registerCallback(vars["id"], input.CallbackURL) {
+		http.Error(w, "unknown order", http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (l *lab) handleAppointment(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	fmt.Fprintf(w, "Your collection site appointment for order %s is booked.\n", vars["id"])
+}
@@ -0,0 +1,106 @@
+package api
+
+import (
+	"encoding/json"
+	"math/rand"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// DefaultInternationalSearchLatency is how long a search takes in a country with a latency factor of 1.
+const DefaultInternationalSearchLatency = time.Second * 30
+
+type InternationalCriminalSearchInput struct {
+	FullName string
+}
+
+type InternationalCriminalSearchResult struct {
+	Complete bool
+	Crimes   []string
+}
+
+// countryLatencyFactors lists the countries the international vendor can search,
+// and how much longer than the base latency searches in each country take.
+var countryLatencyFactors = map[string]int{
+	"AU": 1,
+	"CA": 1,
+	"DE": 2,
+	"FR": 2,
+	"GB": 1,
+	"IN": 4,
+	"JP": 3,
+	"MX": 3,
+}
+
+type internationalSearch struct {
+	ReadyAt time.Time
+	Crimes  []string
+}
+
+// internationalVendor simulates a vendor that searches criminal records abroad. A search starts the first time
+// it is requested, and reports as incomplete until the country's latency has passed.
+type internationalVendor struct {
+	sync.Mutex
+	latency  time.Duration
+	searches map[string]*internationalSearch
+}
+
+func newInternationalVendor(latency time.Duration) *internationalVendor {
+	return &internationalVendor{latency: latency, searches: make(map[string]*internationalSearch)}
+}
+
+func (v *internationalVendor) search(country string, factor int, fullName string) InternationalCriminalSearchResult {
+	v.Lock()
+	defer v.Unlock()
+
+	key := country + "/" + strings.ToLower(fullName)
+
+	s, ok := v.searches[key]
+	if !ok {
+		s = &internationalSearch{ReadyAt: time.Now().Add(v.latency * time.Duration(factor))}
+
+		possibleCrimes := []string{
+			"Fraud",
+			"Theft",
+			"Assault",
+		}
+		if rand.Intn(100) > 75 {
+			s.Crimes = append(s.Crimes, possibleCrimes[rand.Intn(len(possibleCrimes))])
+		}
+
+		v.searches[key] = s
+	}
+
+	if time.Now().Before(s.ReadyAt) {
+		return InternationalCriminalSearchResult{}
+	}
+
+	return InternationalCriminalSearchResult{Complete: true, Crimes: s.Crimes}
+}
+
+func (v *internationalVendor) handleSearch(w http.ResponseWriter, r *http.Request) {
+	country := strings.ToUpper(mux.Vars(r)["country"])
+
+	factor, ok := countryLatencyFactors[country]
+	if !ok {
+		http.Error(w, "country not supported", http.StatusNotFound)
+		return
+	}
+
+	var input InternationalCriminalSearchInput
+
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result := v.search(country, factor, input.FullName)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
@@ -1,20 +1,32 @@
 package cmd
 
 import (
+	"time"
+
 	"github.com/spf13/cobra"
 
 	"github.com/temporalio/background-checks/deployment/thirdparty-simulator/api"
 )
 
+var (
+	labResultDelay             time.Duration
+	internationalSearchLatency time.Duration
+)
+
 // apiCmd represents the thirdparty command
 var apiCmd = &cobra.Command{
 	Use:   "api",
 	Short: "Starts an API server for the third party API simulator",
 	Run: func(cmd *cobra.Command, args []string) {
-		api.Run()
+		api.Run(api.Options{
+			LabResultDelay:             labResultDelay,
+			InternationalSearchLatency: internationalSearchLatency,
+		})
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(apiCmd)
+	apiCmd.Flags().DurationVar(&labResultDelay, "lab-result-delay", api.DefaultLabResultDelay, "How long the drug screen lab takes to post results back")
+	apiCmd.Flags().DurationVar(&internationalSearchLatency, "international-search-latency", api.DefaultInternationalSearchLatency, "How long international criminal searches take, before each country's latency factor")
 }
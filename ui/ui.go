@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
 	"text/template"
 
 	"github.com/gorilla/mux"
@@ -34,6 +36,74 @@ func (h *handlers) handleAccept(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// educationFromForm collects the education entries from the accept form, skipping any left blank.
+func educationFromForm(r *http.Request) []workflows.EducationRecord {
+	var education []workflows.EducationRecord
+
+	institutions := r.PostForm["education_institution"]
+	degrees := r.PostForm["education_degree"]
+	dates := r.PostForm["education_graduation_date"]
+
+	for i, institution := range institutions {
+		if institution == "" || i >= len(degrees) || i >= len(dates) {
+			continue
+		}
+		education = append(education, workflows.EducationRecord{
+			Institution:    institution,
+			Degree:         degrees[i],
+			GraduationDate: dates[i],
+		})
+	}
+
+	return education
+}
+
+// referencesFromForm collects the references from the accept form, skipping any left blank.
+func referencesFromForm(r *http.Request) []workflows.Reference {
+	var references []workflows.Reference
+
+	names := r.PostForm["reference_name"]
+	emails := r.PostForm["reference_email"]
+	companies := r.PostForm["reference_company"]
+	relationships := r.PostForm["reference_relationship"]
+
+	for i, name := range names {
+		if name == "" || i >= len(emails) || i >= len(companies) || i >= len(relationships) {
+			continue
+		}
+		references = append(references, workflows.Reference{
+			Name:         name,
+			Email:        emails[i],
+			Company:      companies[i],
+			Relationship: relationships[i],
+		})
+	}
+
+	return references
+}
+
+// licensesFromForm collects the professional license entries from the accept form, skipping any left blank.
+func licensesFromForm(r *http.Request) []workflows.LicenseRecord {
+	var licenses []workflows.LicenseRecord
+
+	types := r.PostForm["license_type"]
+	numbers := r.PostForm["license_number"]
+	states := r.PostForm["license_state"]
+
+	for i, licenseType := range types {
+		if licenseType == "" || i >= len(numbers) || i >= len(states) {
+			continue
+		}
+		licenses = append(licenses, workflows.LicenseRecord{
+			Type:   licenseType,
+			Number: numbers[i],
+			State:  states[i],
+		})
+	}
+
+	return licenses
+}
+
 //go:embed accepted.go.html
 var acceptedHTML string
 var acceptedHTMLTemplate = template.Must(template.New("accepted").Parse(acceptedHTML))
@@ -85,12 +155,20 @@ func (h *handlers) handleAcceptSubmission(w http.ResponseWriter, r *http.Request
 	}
 
 	candidatedetails := workflows.CandidateDetails{
-		FullName: r.FormValue("full_name"),
-		SSN:      r.FormValue("ssn"),
-		Employer: r.FormValue("employer"),
+		FullName:   r.FormValue("full_name"),
+		Aliases:    workflows.MergeAliases(r.FormValue("full_name"), strings.Split(r.FormValue("aliases"), ","), nil),
+		SSN:        r.FormValue("ssn"),
+		DOB:        r.FormValue("dob"),
+		Address:    r.FormValue("address"),
+		Employer:   r.FormValue("employer"),
+		Education:  educationFromForm(r),
+		Licenses:   licensesFromForm(r),
+		Countries:  workflows.NormalizeCountries(strings.Split(r.FormValue("countries"), ",")),
+		References: referencesFromForm(r),
 	}
 	submission := workflows.AcceptSubmissionSignal{
 		CandidateDetails: candidatedetails,
+		CreditConsent:    r.FormValue("credit_consent") == "yes",
 	}
 
 	response, err := utils.PostJSON(requestURL, submission)
@@ -189,6 +267,164 @@ func (h *handlers) handleEmploymentVerificationSubmission(w http.ResponseWriter,
 	}
 }
 
+//go:embed researcher_review.go.html
+var researcherReviewHTML string
+var researcherReviewHTMLTemplate = template.Must(template.New("researcher_review").Parse(researcherReviewHTML))
+
+func (h *handlers) handleResearcherReview(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	token := vars["token"]
+
+	router := api.Router(nil)
+
+	requestURL, err := router.Get("review_details").Host(APIEndpoint).URL("token", token)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var review workflows.ResearcherReviewDetails
+
+	_, err = utils.GetJSON(requestURL, &review)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	err = researcherReviewHTMLTemplate.Execute(w, map[string]interface{}{"Token": token, "Review": review})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+//go:embed researcher_reviewed.go.html
+var researcherReviewedHTML string
+var researcherReviewedHTMLTemplate = template.Must(template.New("researcher_reviewed").Parse(researcherReviewedHTML))
+
+func (h *handlers) handleResearcherReviewSubmission(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	token := vars["token"]
+
+	router := api.Router(nil)
+
+	requestURL, err := router.Get("review").Host(APIEndpoint).URL("token", token)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	submission := workflows.ResearcherReviewSubmissionSignal{
+		Confirmed: r.FormValue("action") == "yes",
+		Notes:     r.FormValue("notes"),
+	}
+
+	response, err := utils.PostJSON(requestURL, submission)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer response.Body.Close()
+
+	body, _ := io.ReadAll(response.Body)
+
+	if response.StatusCode != http.StatusOK {
+		message := fmt.Sprintf("%s: %s", http.StatusText(response.StatusCode), body)
+		http.Error(w, message, http.StatusInternalServerError)
+		return
+	}
+
+	err = researcherReviewedHTMLTemplate.Execute(w, nil)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+//go:embed reference.go.html
+var referenceHTML string
+var referenceHTMLTemplate = template.Must(template.New("reference").Parse(referenceHTML))
+
+func (h *handlers) handleReference(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	token := vars["token"]
+
+	router := api.Router(nil)
+
+	requestURL, err := router.Get("reference_details").Host(APIEndpoint).URL("token", token)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var reference workflows.ReferenceDetails
+
+	_, err = utils.GetJSON(requestURL, &reference)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	err = referenceHTMLTemplate.Execute(w, map[string]interface{}{"Token": token, "Reference": reference})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+//go:embed referenced.go.html
+var referencedHTML string
+var referencedHTMLTemplate = template.Must(template.New("referenced").Parse(referencedHTML))
+
+func (h *handlers) handleReferenceSubmission(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	token := vars["token"]
+
+	router := api.Router(nil)
+
+	requestURL, err := router.Get("reference").Host(APIEndpoint).URL("token", token)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	yearsKnown, _ := strconv.Atoi(r.FormValue("years_known"))
+	rating, _ := strconv.Atoi(r.FormValue("rating"))
+
+	submission := workflows.ReferenceResponseSignal{
+		Response: workflows.ReferenceResponse{
+			Relationship: r.FormValue("relationship"),
+			YearsKnown:   yearsKnown,
+			Strengths:    r.FormValue("strengths"),
+			Improvements: r.FormValue("improvements"),
+			Rating:       rating,
+			WouldRehire:  r.FormValue("would_rehire") == "yes",
+			Comments:     r.FormValue("comments"),
+		},
+	}
+
+	response, err := utils.PostJSON(requestURL, submission)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer response.Body.Close()
+
+	body, _ := io.ReadAll(response.Body)
+
+	if response.StatusCode != http.StatusOK {
+		message := fmt.Sprintf("%s: %s", http.StatusText(response.StatusCode), body)
+		http.Error(w, message, http.StatusInternalServerError)
+		return
+	}
+
+	err = referencedHTMLTemplate.Execute(w, nil)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
 //go:embed report.go.html
 var reportHTML string
 var reportHTMLTemplate = template.Must(template.New("report").Parse(reportHTML))
@@ -231,6 +467,12 @@ func Router() *mux.Router {
 	r.HandleFunc("/employment/{token}", h.handleEmploymentVerification).Methods("GET")
 	r.HandleFunc("/employment/{token}", h.handleEmploymentVerificationSubmission).Methods("POST")
 
+	r.HandleFunc("/review/{token}", h.handleResearcherReview).Methods("GET")
+	r.HandleFunc("/review/{token}", h.handleResearcherReviewSubmission).Methods("POST")
+
+	r.HandleFunc("/reference/{token}", h.handleReference).Methods("GET")
+	r.HandleFunc("/reference/{token}", h.handleReferenceSubmission).Methods("POST")
+
 	r.HandleFunc("/report/{token}", h.handleReport).Methods("GET")
 
 	return r
@@ -0,0 +1,185 @@
+package watchlist
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// transliterations maps letters that are commonly written differently in other scripts or without diacritics
+// to their plain Latin equivalents, so that "Müller" matches "Mueller" and "Мельников" matches "Melnikov".
+var transliterations = map[rune]string{
+	'à': "a", 'á': "a", 'â': "a", 'ã': "a", 'å': "a", 'ā': "a", 'ą': "a", 'ä': "ae",
+	'ç': "c", 'ć': "c", 'č': "c",
+	'ď': "d", 'đ': "d",
+	'è': "e", 'é': "e", 'ê': "e", 'ë': "e", 'ē': "e", 'ę': "e", 'ě': "e",
+	'ì': "i", 'í': "i", 'î': "i", 'ï': "i", 'ī': "i", 'ı': "i",
+	'ł': "l",
+	'ñ': "n", 'ń': "n", 'ň': "n",
+	'ò': "o", 'ó': "o", 'ô': "o", 'õ': "o", 'ø': "o", 'ō': "o", 'ö': "oe",
+	'ř': "r",
+	'ś': "s", 'š': "s", 'ş': "s", 'ß': "ss",
+	'ť': "t",
+	'ù': "u", 'ú': "u", 'û': "u", 'ū': "u", 'ů': "u", 'ü': "ue",
+	'ý': "y", 'ÿ': "y",
+	'ź': "z", 'ż': "z", 'ž': "z",
+	'æ': "ae", 'œ': "oe",
+
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh", 'з': "z", 'и': "i",
+	'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t",
+	'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "",
+	'э': "e", 'ю': "yu", 'я': "ya", 'і': "i", 'ї': "yi", 'є': "ye",
+
+	// Apostrophes are dropped rather than splitting the name, so "O'Brien" matches "OBrien".
+	'\'': "", '’': "",
+}
+
+// Normalize transliterates a name to plain lower case Latin letters and splits it into tokens.
+// The tokens are sorted so that names match regardless of the order they are written in.
+func Normalize(name string) []string {
+	var b strings.Builder
+
+	for _, r := range strings.ToLower(name) {
+		if t, ok := transliterations[r]; ok {
+			b.WriteString(t)
+			continue
+		}
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			continue
+		}
+		b.WriteRune(' ')
+	}
+
+	tokens := strings.Fields(b.String())
+	sort.Strings(tokens)
+
+	return tokens
+}
+
+// levenshtein returns the edit distance between two strings.
+func levenshtein(a, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
+
+func min(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
+
+// similarity is the edit distance between two strings scaled to between 0 (nothing in common) and 1 (identical).
+func similarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+	if longest == 0 {
+		return 0
+	}
+
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+// skeleton reduces a token to its first letter and following consonants, with repeated letters collapsed.
+// Different romanizations of the same name, such as "Mohammed" and "Muhammad", usually share a skeleton.
+func skeleton(token string) string {
+	var b strings.Builder
+	var last rune
+
+	for i, r := range token {
+		if i > 0 && strings.ContainsRune("aeiouy", r) {
+			continue
+		}
+		if r == last {
+			continue
+		}
+		b.WriteRune(r)
+		last = r
+	}
+
+	return b.String()
+}
+
+// tokenSimilarity compares two tokens, allowing for spelling variations. Skeleton matches score slightly lower
+// than exact matches, as they are more likely to be different names.
+func tokenSimilarity(a, b string) float64 {
+	score := similarity(a, b)
+	if s := similarity(skeleton(a), skeleton(b)) * 0.9; s > score {
+		score = s
+	}
+	return score
+}
+
+// tokenScore matches each token of the shorter name against its closest token in the longer name.
+// Names with tokens missing from the other, such as a middle name, are penalized slightly.
+// A single token name against a longer one is penalized heavily, so that a surname alone is not a match.
+func tokenScore(a, b []string) float64 {
+	short, long := a, b
+	if len(short) > len(long) {
+		short, long = long, short
+	}
+
+	var total float64
+	for _, s := range short {
+		var best float64
+		for _, l := range long {
+			if score := tokenSimilarity(s, l); score > best {
+				best = score
+			}
+		}
+		total += best
+	}
+
+	average := total / float64(len(short))
+	coverage := float64(len(short)) / float64(len(long))
+
+	if len(short) == 1 && len(long) > 1 {
+		return average * coverage
+	}
+
+	return average * (0.8 + 0.2*coverage)
+}
+
+// Score returns how closely two names match, between 0 and 1.
+// Names are compared after transliteration, ignoring the order they are written in,
+// and allowing for spelling mistakes and variations.
+func Score(a, b string) float64 {
+	ta, tb := Normalize(a), Normalize(b)
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0
+	}
+
+	// Comparing the names as a whole handles names split differently, such as "Al Hassan" and "Alhassan".
+	score := similarity(strings.Join(ta, ""), strings.Join(tb, ""))
+	if s := tokenScore(ta, tb); s > score {
+		score = s
+	}
+
+	return score
+}
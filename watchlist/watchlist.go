@@ -0,0 +1,128 @@
+// Package watchlist screens names against sanctions and watch lists, such as the OFAC SDN list.
+//
+// Lists are loaded from a local JSON file, which is reloaded whenever it changes, so the list can be refreshed
+// by replacing the file without restarting the worker.
+package watchlist
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultThreshold is the score at or above which a name is reported as a possible match.
+const DefaultThreshold = 0.85
+
+// Entry is a person on a sanctions or watch list.
+type Entry struct {
+	ID      string
+	Name    string
+	Aliases []string
+	// List is the list the entry was published on, such as "OFAC SDN".
+	List string
+	// Program is the sanctions program or reason the person is listed.
+	Program string
+	Country string
+}
+
+// Hit is a possible match between one of the names screened and a list entry.
+type Hit struct {
+	// Name is the name that was screened.
+	Name string
+	// MatchedName is the entry's name or alias that matched.
+	MatchedName string
+	EntryID     string
+	List        string
+	Program     string
+	Score       float64
+}
+
+// Source is a watch list loaded from a file. It is safe for concurrent use.
+type Source struct {
+	path string
+
+	mu      sync.Mutex
+	modTime time.Time
+	entries []Entry
+}
+
+// NewSource returns a Source for the list at path. The file is not read until the list is first used.
+func NewSource(path string) *Source {
+	return &Source{path: path}
+}
+
+// Entries returns the entries on the list, reloading the file first if it has changed since it was last read.
+func (s *Source) Entries() ([]Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.entries != nil && info.ModTime().Equal(s.modTime) {
+		return s.entries, nil
+	}
+
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	err = json.Unmarshal(data, &entries)
+	if err != nil {
+		return nil, fmt.Errorf("invalid watch list %s: %w", s.path, err)
+	}
+	if entries == nil {
+		entries = []Entry{}
+	}
+
+	s.entries = entries
+	s.modTime = info.ModTime()
+
+	return s.entries, nil
+}
+
+// Screen checks each of names against every entry on the list, returning any hits scoring at least threshold,
+// best first. Each name is reported at most once per entry, against the entry's closest name or alias.
+func (s *Source) Screen(names []string, threshold float64) ([]Hit, error) {
+	entries, err := s.Entries()
+	if err != nil {
+		return nil, err
+	}
+
+	return Screen(entries, names, threshold), nil
+}
+
+// Screen checks each of names against entries, as Source.Screen.
+func Screen(entries []Entry, names []string, threshold float64) []Hit {
+	var hits []Hit
+
+	for _, name := range names {
+		for _, entry := range entries {
+			best := Hit{Name: name, EntryID: entry.ID, List: entry.List, Program: entry.Program}
+
+			for _, listed := range append([]string{entry.Name}, entry.Aliases...) {
+				if score := Score(name, listed); score > best.Score {
+					best.Score = score
+					best.MatchedName = listed
+				}
+			}
+
+			if best.Score >= threshold {
+				hits = append(hits, best)
+			}
+		}
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		return hits[i].Score > hits[j].Score
+	})
+
+	return hits
+}
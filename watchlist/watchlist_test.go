@@ -0,0 +1,62 @@
+package watchlist_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/temporalio/background-checks/watchlist"
+)
+
+func TestScore(t *testing.T) {
+	tests := []struct {
+		a, b  string
+		match bool
+	}{
+		{"John Smith", "John Smith", true},
+		{"Smith, John", "John Smith", true},
+		{"Jurgen Schaefer", "Jürgen Schäfer", true},
+		{"Viktor Melnikov", "Виктор Мельников", true},
+		{"Muhammad Al-Hassan", "Mohammed Al Hassan", true},
+		{"Mohammed Alhassan", "Mohammed Al Hassan", true},
+		{"Jose Luis Ramirez", "José Luis Ramírez Ortega", true},
+		{"Jon Smith", "John Smith", true},
+		{"Smith", "John Smith", false},
+		{"Jane Doe", "John Smith", false},
+		{"Kim Jong Un", "Kim Chol Su", false},
+	}
+
+	for _, test := range tests {
+		score := watchlist.Score(test.a, test.b)
+		assert.Equal(t, test.match, score >= watchlist.DefaultThreshold, "%q vs %q scored %.2f", test.a, test.b, score)
+	}
+}
+
+func TestSourceScreen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "watchlist.json")
+
+	err := os.WriteFile(path, []byte(`[{"ID": "1", "Name": "Viktor Melnikov", "Aliases": ["Victor Melnikoff"], "List": "OFAC SDN"}]`), 0644)
+	assert.NoError(t, err)
+
+	source := watchlist.NewSource(path)
+
+	hits, err := source.Screen([]string{"Jane Doe", "Victor Melnikov"}, watchlist.DefaultThreshold)
+	assert.NoError(t, err)
+	assert.Len(t, hits, 1)
+	assert.Equal(t, "Victor Melnikov", hits[0].Name)
+	assert.Equal(t, "1", hits[0].EntryID)
+
+	// Publishing a new list is picked up without having to create a new source.
+	err = os.WriteFile(path, []byte(`[{"ID": "2", "Name": "Jane Doe", "List": "UN Consolidated"}]`), 0644)
+	assert.NoError(t, err)
+	later := time.Now().Add(time.Minute)
+	assert.NoError(t, os.Chtimes(path, later, later))
+
+	hits, err = source.Screen([]string{"Jane Doe", "Victor Melnikov"}, watchlist.DefaultThreshold)
+	assert.NoError(t, err)
+	assert.Len(t, hits, 1)
+	assert.Equal(t, "Jane Doe", hits[0].Name)
+	assert.Equal(t, "2", hits[0].EntryID)
+}
@@ -10,13 +10,21 @@ import (
 const (
 	AcceptSubmissionSignalName = "accept-submission"
 	AcceptGracePeriod          = time.Hour * 24 * 7
+	// DefaultUnresponsiveNoticePeriod is how long before the deadline we let the Hiring Manager know the candidate
+	// hasn't responded, unless the check sets its own.
+	DefaultUnresponsiveNoticePeriod = time.Hour * 24
 )
 
+// DefaultAcceptReminders are the times, after the initial request, at which the candidate is reminded to respond.
+var DefaultAcceptReminders = []time.Duration{
+	time.Hour * 24 * 2,
+	time.Hour * 24 * 5,
+}
+
 func emailCandidate(ctx workflow.Context, input *AcceptWorkflowInput) error {
 	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
 		StartToCloseTimeout: time.Minute,
 	})
-
 	i := activities.SendAcceptEmailInput{
 		Email: input.Email,
 		Token: TokenForWorkflow(ctx),
@@ -25,9 +33,52 @@ func emailCandidate(ctx workflow.Context, input *AcceptWorkflowInput) error {
 	return f.Get(ctx, nil)
 }
 
-func waitForSubmission(ctx workflow.Context) (*AcceptSubmission, error) {
+func emailCandidateReminder(ctx workflow.Context, input *AcceptWorkflowInput, deadline time.Time) error {
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: time.Minute,
+	})
+	i := activities.SendAcceptReminderEmailInput{
+		Email:    input.Email,
+		Token:    TokenForWorkflow(ctx),
+		Deadline: deadline,
+	}
+	f := workflow.ExecuteActivity(ctx, a.SendAcceptReminderEmail, i)
+	return f.Get(ctx, nil)
+}
+
+func emailHiringManagerUnresponsive(ctx workflow.Context, input *AcceptWorkflowInput, deadline time.Time) error {
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: time.Minute,
+	})
+	i := activities.SendCandidateUnresponsiveEmailInput{
+		Email:    input.Email,
+		Deadline: deadline,
+	}
+	f := workflow.ExecuteActivity(ctx, a.SendCandidateUnresponsiveEmail, i)
+	return f.Get(ctx, nil)
+}
+
+// waitForSubmission waits for the candidate to respond, sending reminders to the candidate and a notice to the
+// Hiring Manager along the way. If the candidate doesn't respond in time the response is marked as expired.
+func waitForSubmission(ctx workflow.Context, input *AcceptWorkflowInput) (*AcceptSubmission, error) {
 	var response AcceptSubmission
-	var err error
+	var done bool
+
+	logger := workflow.GetLogger(ctx)
+	deadline := workflow.Now(ctx).Add(AcceptGracePeriod)
+
+	reminders := input.Reminders
+	if reminders == nil {
+		reminders = DefaultAcceptReminders
+	}
+	noticePeriod := input.UnresponsiveNoticePeriod
+	if noticePeriod <= 0 || noticePeriod >= AcceptGracePeriod {
+		noticePeriod = DefaultUnresponsiveNoticePeriod
+	}
+
+	// Cancel any outstanding timers once we have our answer.
+	ctx, cancelTimers := workflow.WithCancel(ctx)
+	defer cancelTimers()
 
 	s := workflow.NewSelector(ctx)
 
@@ -35,38 +86,79 @@ func waitForSubmission(ctx workflow.Context) (*AcceptSubmission, error) {
 	s.AddReceive(ch, func(c workflow.ReceiveChannel, more bool) {
 		var submission AcceptSubmissionSignal
 		c.Receive(ctx, &submission)
+		response = AcceptSubmission{
+			Accepted:         submission.Accepted,
+			CandidateDetails: submission.CandidateDetails,
+			CreditConsent:    submission.CreditConsent,
+		}
+		done = true
+	})
 
-		response = AcceptSubmission(submission)
+	for _, after := range reminders {
+		if after >= AcceptGracePeriod {
+			continue
+		}
+		s.AddFuture(workflow.NewTimer(ctx, after), func(f workflow.Future) {
+			// A failed reminder shouldn't stop the candidate from responding.
+			err := emailCandidateReminder(ctx, input, deadline)
+			if err != nil {
+				logger.Error("Failed to send reminder", "error", err)
+			}
+		})
+	}
+
+	s.AddFuture(workflow.NewTimer(ctx, AcceptGracePeriod-noticePeriod), func(f workflow.Future) {
+		err := emailHiringManagerUnresponsive(ctx, input, deadline)
+		if err != nil {
+			logger.Error("Failed to send unresponsive notice", "error", err)
+		}
 	})
-	s.AddFuture(workflow.NewTimer(ctx, AcceptGracePeriod), func(f workflow.Future) {
-		err = f.Get(ctx, nil)
 
-		// Treat failure to accept in time as declining.
+	s.AddFuture(workflow.NewTimer(ctx, AcceptGracePeriod), func(f workflow.Future) {
+		// Failure to accept in time is reported as expired, rather than as the candidate declining.
 		response.Accepted = false
+		response.Expired = true
+		done = true
 	})
 
-	s.Select(ctx)
+	for !done {
+		s.Select(ctx)
 
-	return &response, err
+		// If the check is cancelled our timers fire early, so stop rather than treat the request as expired.
+		if ctx.Err() != nil {
+			return &response, ctx.Err()
+		}
+	}
+
+	return &response, nil
 }
 
 type AcceptWorkflowInput struct {
 	Email string
+	// Reminders are the times, after the initial request, at which the candidate is reminded to respond.
+	// DefaultAcceptReminders is used if it is not set.
+	Reminders []time.Duration
+	// UnresponsiveNoticePeriod is how long before the deadline the Hiring Manager is told the candidate hasn't
+	// responded. DefaultUnresponsiveNoticePeriod is used if it is not set, or is not within the grace period.
+	UnresponsiveNoticePeriod time.Duration
 }
 
 type AcceptWorkflowResult struct {
 	Accepted         bool
+	Expired          bool
 	CandidateDetails CandidateDetails
+	CreditConsent    bool
 }
 
 // @@@SNIPSTART background-checks-accept-workflow-definition
+
 func Accept(ctx workflow.Context, input *AcceptWorkflowInput) (*AcceptWorkflowResult, error) {
 	err := emailCandidate(ctx, input)
 	if err != nil {
 		return &AcceptWorkflowResult{}, err
 	}
 
-	submission, err := waitForSubmission(ctx)
+	submission, err := waitForSubmission(ctx, input)
 
 	result := AcceptWorkflowResult(*submission)
 	return &result, err
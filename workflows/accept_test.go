@@ -1,9 +1,12 @@
 package workflows_test
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/temporalio/background-checks/activities"
 	"github.com/temporalio/background-checks/workflows"
 	"go.temporal.io/sdk/testsuite"
@@ -48,6 +51,8 @@ func TestReturnsAcceptWorkflowTimeout(t *testing.T) {
 	a := activities.Activities{SMTPStub: true}
 
 	env.RegisterActivity(a.SendAcceptEmail)
+	env.RegisterActivity(a.SendAcceptReminderEmail)
+	env.RegisterActivity(a.SendCandidateUnresponsiveEmail)
 
 	env.ExecuteWorkflow(workflows.Accept, &workflows.AcceptWorkflowInput{})
 
@@ -55,5 +60,111 @@ func TestReturnsAcceptWorkflowTimeout(t *testing.T) {
 	err := env.GetWorkflowResult(&result)
 	assert.NoError(t, err)
 
-	assert.Equal(t, workflows.AcceptWorkflowResult{Accepted: false, CandidateDetails: workflows.CandidateDetails{}}, result)
+	assert.Equal(t, workflows.AcceptWorkflowResult{Accepted: false, Expired: true, CandidateDetails: workflows.CandidateDetails{}}, result)
+}
+
+func TestAcceptWorkflowSendsRemindersAndUnresponsiveNotice(t *testing.T) {
+	s := testsuite.WorkflowTestSuite{}
+	env := s.NewTestWorkflowEnvironment()
+	a := activities.Activities{SMTPStub: true}
+
+	env.RegisterActivity(a.SendAcceptEmail)
+
+	reminders := 0
+	env.OnActivity(a.SendAcceptReminderEmail, mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, input *activities.SendAcceptReminderEmailInput) (*activities.SendAcceptReminderEmailResult, error) {
+			reminders++
+			return &activities.SendAcceptReminderEmailResult{}, nil
+		},
+	)
+
+	notices := 0
+	env.OnActivity(a.SendCandidateUnresponsiveEmail, mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, input *activities.SendCandidateUnresponsiveEmailInput) (*activities.SendCandidateUnresponsiveEmailResult, error) {
+			notices++
+			return &activities.SendCandidateUnresponsiveEmailResult{}, nil
+		},
+	)
+
+	env.ExecuteWorkflow(workflows.Accept, &workflows.AcceptWorkflowInput{
+		Email:     "john.smith@example.com",
+		Reminders: []time.Duration{time.Hour * 24, time.Hour * 24 * 3, time.Hour * 24 * 5},
+	})
+
+	var result workflows.AcceptWorkflowResult
+	err := env.GetWorkflowResult(&result)
+	assert.NoError(t, err)
+
+	assert.True(t, result.Expired)
+	assert.Equal(t, 3, reminders)
+	assert.Equal(t, 1, notices)
+}
+
+func TestAcceptWorkflowStopsRemindersOnceAccepted(t *testing.T) {
+	s := testsuite.WorkflowTestSuite{}
+	env := s.NewTestWorkflowEnvironment()
+	a := activities.Activities{SMTPStub: true}
+
+	env.RegisterActivity(a.SendAcceptEmail)
+
+	reminders := 0
+	env.OnActivity(a.SendAcceptReminderEmail, mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, input *activities.SendAcceptReminderEmailInput) (*activities.SendAcceptReminderEmailResult, error) {
+			reminders++
+			return &activities.SendAcceptReminderEmailResult{}, nil
+		},
+	)
+	env.RegisterActivity(a.SendCandidateUnresponsiveEmail)
+
+	env.RegisterDelayedCallback(
+		func() {
+			env.SignalWorkflow(
+				workflows.AcceptSubmissionSignalName,
+				workflows.AcceptSubmissionSignal{Accepted: true},
+			)
+		},
+		time.Hour*24*3,
+	)
+
+	env.ExecuteWorkflow(workflows.Accept, &workflows.AcceptWorkflowInput{})
+
+	var result workflows.AcceptWorkflowResult
+	err := env.GetWorkflowResult(&result)
+	assert.NoError(t, err)
+
+	assert.True(t, result.Accepted)
+	assert.False(t, result.Expired)
+	assert.Equal(t, 1, reminders)
+}
+
+func TestAcceptWorkflowUnresponsiveNoticePeriod(t *testing.T) {
+	s := testsuite.WorkflowTestSuite{}
+	env := s.NewTestWorkflowEnvironment()
+	a := activities.Activities{SMTPStub: true}
+
+	env.RegisterActivity(a.SendAcceptEmail)
+	env.RegisterActivity(a.SendAcceptReminderEmail)
+
+	var noticeSentAt time.Time
+	env.OnActivity(a.SendCandidateUnresponsiveEmail, mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, input *activities.SendCandidateUnresponsiveEmailInput) (*activities.SendCandidateUnresponsiveEmailResult, error) {
+			noticeSentAt = env.Now()
+			return &activities.SendCandidateUnresponsiveEmailResult{}, nil
+		},
+	)
+
+	start := env.Now()
+	env.ExecuteWorkflow(workflows.Accept, &workflows.AcceptWorkflowInput{
+		Email:                    "john.smith@example.com",
+		Reminders:                []time.Duration{},
+		UnresponsiveNoticePeriod: time.Hour * 24 * 3,
+	})
+
+	var result workflows.AcceptWorkflowResult
+	err := env.GetWorkflowResult(&result)
+	assert.NoError(t, err)
+
+	// The Hiring Manager is told three days before the deadline, rather than the default of one.
+	assert.True(t, result.Expired)
+	assert.Equal(t, workflows.AcceptGracePeriod-time.Hour*24*3, noticeSentAt.Sub(start))
 }
@@ -0,0 +1,215 @@
+package workflows
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/temporalio/background-checks/activities"
+)
+
+// zipCounties maps ZIP codes to the county whose courthouse holds criminal records for the address.
+var zipCounties = map[string]string{
+	"02215": "Suffolk",
+	"10011": "New York",
+	"10451": "Bronx",
+	"11368": "Queens",
+	"21201": "Baltimore City",
+	"60613": "Cook",
+	"62706": "Sangamon",
+	"63102": "St. Louis City",
+	"64129": "Jackson",
+}
+
+var (
+	stateCode = regexp.MustCompile(`^[A-Z]{2}$`)
+	zipCode   = regexp.MustCompile(`^(\d{5})(-\d{4})?$`)
+)
+
+// ParseAddress parses an address in the form "street, city, ST 12345" as returned by the SSN trace,
+// and looks up its county from the ZIP code. County is left blank if the ZIP code is not one we know.
+func ParseAddress(address string) (KnownAddress, error) {
+	var result KnownAddress
+
+	parts := strings.Split(address, ",")
+	if len(parts) < 3 {
+		return result, fmt.Errorf("expected street, city, state and ZIP code: %q", address)
+	}
+
+	stateZip := strings.Fields(parts[len(parts)-1])
+	if len(stateZip) != 2 {
+		return result, fmt.Errorf("expected state and ZIP code: %q", address)
+	}
+
+	state := strings.ToUpper(stateZip[0])
+	if !stateCode.MatchString(state) {
+		return result, fmt.Errorf("invalid state %q: %q", stateZip[0], address)
+	}
+
+	zip := zipCode.FindStringSubmatch(stateZip[1])
+	if zip == nil {
+		return result, fmt.Errorf("invalid ZIP code %q: %q", stateZip[1], address)
+	}
+
+	result.Address = strings.TrimSpace(strings.Join(parts[:len(parts)-2], ","))
+	result.City = strings.TrimSpace(parts[len(parts)-2])
+	result.State = state
+	result.ZipCode = zip[1]
+	result.County = zipCounties[result.ZipCode]
+
+	return result, nil
+}
+
+// ssnTraceDateLayout is the format of the dates in the SSN trace's address history.
+const ssnTraceDateLayout = "2006-01-02"
+
+// String formats the address in the form "street, city, ST 12345", which is what our vendors expect.
+func (k KnownAddress) String() string {
+	return fmt.Sprintf("%s, %s, %s %s", k.Address, k.City, k.State, k.ZipCode)
+}
+
+// Current reports whether the address is the candidate's current address.
+func (k KnownAddress) Current() bool {
+	return k.To.IsZero()
+}
+
+// SamePlace reports whether two addresses refer to the same place, ignoring differences in punctuation,
+// spacing, case and abbreviations in the street address.
+func (k KnownAddress) SamePlace(other KnownAddress) bool {
+	return k.ZipCode == other.ZipCode && normalizeStreet(k.Address) == normalizeStreet(other.Address)
+}
+
+// streetAbbreviations are the USPS abbreviations for the words most often spelled out in street addresses.
+var streetAbbreviations = map[string]string{
+	"north":     "n",
+	"south":     "s",
+	"east":      "e",
+	"west":      "w",
+	"street":    "st",
+	"avenue":    "ave",
+	"road":      "rd",
+	"drive":     "dr",
+	"lane":      "ln",
+	"boulevard": "blvd",
+	"court":     "ct",
+	"place":     "pl",
+	"apartment": "apt",
+	"suite":     "ste",
+}
+
+// normalizeStreet reduces a street address to lower case, abbreviated words so that "1 E. 161 St" matches
+// "1 East 161 street".
+func normalizeStreet(street string) string {
+	words := strings.Fields(strings.Map(func(r rune) rune {
+		if r == '.' || r == ',' || r == '#' {
+			return ' '
+		}
+		return r
+	}, strings.ToLower(street)))
+
+	for i, word := range words {
+		if abbreviation, ok := streetAbbreviations[word]; ok {
+			words[i] = abbreviation
+		}
+	}
+
+	return strings.Join(words, " ")
+}
+
+// formatAddresses formats a list of addresses for display, separated by semicolons.
+func formatAddresses(addresses []KnownAddress) string {
+	formatted := make([]string, len(addresses))
+	for i, address := range addresses {
+		formatted[i] = address.String()
+	}
+	return strings.Join(formatted, "; ")
+}
+
+// parseTraceAddress parses an address from the SSN trace's address history, along with the dates it was in use.
+func parseTraceAddress(raw activities.SSNTraceAddress) (KnownAddress, error) {
+	address, err := ParseAddress(strings.Join(strings.Fields(raw.Address), " "))
+	if err != nil {
+		return address, err
+	}
+
+	if raw.From != "" {
+		address.From, err = time.Parse(ssnTraceDateLayout, raw.From)
+		if err != nil {
+			return address, fmt.Errorf("invalid from date %q: %q", raw.From, raw.Address)
+		}
+	}
+	if raw.To != "" {
+		address.To, err = time.Parse(ssnTraceDateLayout, raw.To)
+		if err != nil {
+			return address, fmt.Errorf("invalid to date %q: %q", raw.To, raw.Address)
+		}
+		if address.To.Before(address.From) {
+			return address, fmt.Errorf("address was left before it was moved into: %q", raw.Address)
+		}
+	}
+
+	return address, nil
+}
+
+// NormalizeAddressHistory parses the address history returned by the SSN trace. Entries for the same place are
+// merged into one, covering all the dates the place was in use, and the result is ordered with the current address
+// first followed by the rest, most recent first. Entries that can't be used are dropped, and the problems with them
+// are returned so that they can be logged.
+func NormalizeAddressHistory(raw []activities.SSNTraceAddress) ([]KnownAddress, []error) {
+	var addresses []KnownAddress
+	var problems []error
+
+	for _, r := range raw {
+		address, err := parseTraceAddress(r)
+		if err != nil {
+			problems = append(problems, err)
+			continue
+		}
+
+		merged := false
+		for i := range addresses {
+			if !addresses[i].SamePlace(address) {
+				continue
+			}
+			if !address.From.IsZero() && (addresses[i].From.IsZero() || address.From.Before(addresses[i].From)) {
+				addresses[i].From = address.From
+			}
+			if address.Current() || (!addresses[i].Current() && address.To.After(addresses[i].To)) {
+				addresses[i].To = address.To
+			}
+			merged = true
+			break
+		}
+		if !merged {
+			addresses = append(addresses, address)
+		}
+	}
+
+	sort.SliceStable(addresses, func(i, j int) bool {
+		if addresses[i].Current() != addresses[j].Current() {
+			return addresses[i].Current()
+		}
+		return addresses[i].To.After(addresses[j].To)
+	})
+
+	return addresses, problems
+}
+
+// latestAddressInEachState returns the most recent address the candidate had in each state they have lived in,
+// for searches that cover a whole state. addresses must be ordered most recent first, as the SSN trace returns them.
+func latestAddressInEachState(addresses []KnownAddress) []KnownAddress {
+	var result []KnownAddress
+	seen := make(map[string]bool)
+
+	for _, address := range addresses {
+		if seen[address.State] {
+			continue
+		}
+		seen[address.State] = true
+		result = append(result, address)
+	}
+
+	return result
+}
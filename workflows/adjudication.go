@@ -0,0 +1,156 @@
+package workflows
+
+import (
+	"time"
+
+	"github.com/temporalio/background-checks/activities"
+	"go.temporal.io/sdk/workflow"
+)
+
+const (
+	AdjudicationDecisionSignalName    = "adjudication-decision"
+	AdjudicationDeadline              = time.Hour * 24 * 30
+	DefaultAdverseActionWaitingPeriod = time.Hour * 24 * 5
+
+	AdjudicationDecisionProceed = "proceed"
+	AdjudicationDecisionAdverse = "adverse"
+)
+
+// Adjudication records the hiring decision made after the report is delivered,
+// and the progress of any adverse action taken as a result.
+type Adjudication struct {
+	Decision                   string
+	Reason                     string
+	PreAdverseActionNoticeSent bool
+	WaitingPeriodEnds          time.Time
+	AdverseActionWithdrawn     bool
+	AdverseActionNoticeSent    bool
+}
+
+// ValidAdjudicationDecision reports whether decision is one the workflow understands.
+func ValidAdjudicationDecision(decision string) bool {
+	return decision == AdjudicationDecisionProceed || decision == AdjudicationDecisionAdverse
+}
+
+// sendPreAdverseActionEmail sends the candidate a pre-adverse action notice with a link to a copy of their report.
+func (w *backgroundCheckWorkflow) sendPreAdverseActionEmail(ctx workflow.Context) error {
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: time.Minute,
+	})
+	f := workflow.ExecuteActivity(ctx, a.SendPreAdverseActionEmail, activities.SendPreAdverseActionEmailInput{Email: w.Email, Token: TokenForWorkflow(ctx)})
+	return f.Get(ctx, nil)
+}
+
+// sendAdverseActionEmail sends the candidate the final adverse action notice.
+func (w *backgroundCheckWorkflow) sendAdverseActionEmail(ctx workflow.Context) error {
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: time.Minute,
+	})
+	f := workflow.ExecuteActivity(ctx, a.SendAdverseActionEmail, activities.SendAdverseActionEmailInput{Email: w.Email, Token: TokenForWorkflow(ctx)})
+	return f.Get(ctx, nil)
+}
+
+// waitForDecision waits for the hiring manager to signal a decision, or for the timeout to pass.
+// The returned bool is false if the timeout passed without a decision.
+func (w *backgroundCheckWorkflow) waitForDecision(ctx workflow.Context, timeout time.Duration) (AdjudicationDecisionSignal, bool) {
+	var decision AdjudicationDecisionSignal
+	var received bool
+
+	ctx, cancelTimer := workflow.WithCancel(ctx)
+	defer cancelTimer()
+
+	s := workflow.NewSelector(ctx)
+	ch := workflow.GetSignalChannel(ctx, AdjudicationDecisionSignalName)
+	s.AddReceive(ch, func(c workflow.ReceiveChannel, more bool) {
+		c.Receive(ctx, &decision)
+		received = true
+	})
+	s.AddFuture(workflow.NewTimer(ctx, timeout), func(f workflow.Future) {})
+
+	s.Select(ctx)
+
+	return decision, received
+}
+
+// adjudicate waits for the hiring manager's decision once the report has been delivered.
+// A negative decision starts the FCRA adverse action process: the candidate is sent a pre-adverse action notice,
+// given a waiting period to respond, and then sent the final adverse action notice.
+// The hiring manager can withdraw the adverse action at any point during the waiting period.
+func (w *backgroundCheckWorkflow) adjudicate(ctx workflow.Context) error {
+	err := w.pushStatus(ctx, "pending_decision")
+	if err != nil {
+		return err
+	}
+
+	deadline := workflow.Now(ctx).Add(AdjudicationDeadline)
+
+	for w.Adjudication.Decision != AdjudicationDecisionAdverse {
+		decision, ok := w.waitForDecision(ctx, deadline.Sub(workflow.Now(ctx)))
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if !ok {
+			// No decision was made in time, there is nothing more for us to do.
+			return w.pushStatus(ctx, "completed")
+		}
+		if !ValidAdjudicationDecision(decision.Decision) {
+			w.logger.Warn("Ignoring unknown adjudication decision", "decision", decision.Decision)
+			continue
+		}
+
+		w.Adjudication.Decision = decision.Decision
+		w.Adjudication.Reason = decision.Reason
+
+		if decision.Decision == AdjudicationDecisionProceed {
+			return w.pushStatus(ctx, "completed")
+		}
+	}
+
+	err = w.sendPreAdverseActionEmail(ctx)
+	if err != nil {
+		return err
+	}
+	w.Adjudication.PreAdverseActionNoticeSent = true
+	w.Adjudication.WaitingPeriodEnds = workflow.Now(ctx).Add(w.adverseActionWaitingPeriod)
+
+	err = w.pushStatus(ctx, "pre_adverse_action")
+	if err != nil {
+		return err
+	}
+
+	// Give the candidate time to respond to the notice, unless the hiring manager changes their mind.
+	for {
+		remaining := w.Adjudication.WaitingPeriodEnds.Sub(workflow.Now(ctx))
+		if remaining <= 0 {
+			break
+		}
+
+		decision, ok := w.waitForDecision(ctx, remaining)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if !ok {
+			break
+		}
+		if decision.Decision == AdjudicationDecisionProceed {
+			w.Adjudication.Decision = decision.Decision
+			w.Adjudication.Reason = decision.Reason
+			w.Adjudication.AdverseActionWithdrawn = true
+			return w.pushStatus(ctx, "completed")
+		}
+	}
+
+	// The final decision can't be made while the candidate is still disputing the report.
+	err = w.waitForDisputes(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = w.sendAdverseActionEmail(ctx)
+	if err != nil {
+		return err
+	}
+	w.Adjudication.AdverseActionNoticeSent = true
+
+	return w.pushStatus(ctx, "adverse_action")
+}
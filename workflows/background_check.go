@@ -4,27 +4,76 @@ import (
 	"time"
 
 	"github.com/temporalio/background-checks/activities"
+	"go.temporal.io/api/enums/v1"
 	"go.temporal.io/sdk/log"
 	"go.temporal.io/sdk/workflow"
 )
 
 const (
 	BackgroundCheckStatusQuery = "background-check-status"
+
+	SearchStatusPending   = "pending"
+	SearchStatusRunning   = "running"
+	SearchStatusCompleted = "completed"
+	SearchStatusFailed    = "failed"
 )
 
 type BackgroundCheckWorkflowInput struct {
 	Email string
 	Tier  string
+	// AdverseActionWaitingPeriod is how long the candidate has to respond to a pre-adverse action notice.
+	// DefaultAdverseActionWaitingPeriod is used if it is not set.
+	AdverseActionWaitingPeriod time.Duration
+	// SLA overrides the turnaround SLA of the package, if set.
+	SLA *TurnaroundSLA
+	// AcceptReminders are the times, after the initial request, at which the candidate is reminded to accept the
+	// check. DefaultAcceptReminders is used if it is not set.
+	AcceptReminders []time.Duration
+	// UnresponsiveNoticePeriod is how long before the acceptance deadline the Hiring Manager is told the candidate
+	// hasn't responded. DefaultUnresponsiveNoticePeriod is used if it is not set.
+	UnresponsiveNoticePeriod time.Duration
+}
+
+// SearchProgress tracks one of the searches in a background check, so that progress can be shown before the check
+// is complete. A search is pending until its workflow has started, and running until its result is collected.
+type SearchProgress struct {
+	Name        string
+	Status      string
+	ScheduledAt time.Time
+	StartedAt   time.Time
+	CompletedAt time.Time
 }
 
 type BackgroundCheckState struct {
 	Email            string
 	Tier             string
 	Accepted         bool
+	Expired          bool
 	CandidateDetails CandidateDetails
+	CreditConsent    bool
 	SSNTrace         *SSNTraceWorkflowResult
-	SearchResults    map[string]interface{}
-	SearchErrors     map[string]string
+	Identity         *IdentityVerificationWorkflowResult
+	// Delayed is set once the check has passed one of the warning points of its turnaround SLA.
+	Delayed bool
+	// Cancellation records who cancelled the check and why, if it was cancelled.
+	Cancellation *Cancellation
+	// Searches is the progress of each search that has been started, in the order they were started.
+	Searches      []SearchProgress
+	SearchResults map[string]interface{}
+	SearchErrors  map[string]SearchError
+	// RefusedSearches holds the reason for each search in the package that we were not permitted to run.
+	RefusedSearches map[string]string
+	Adjudication    Adjudication
+	ReportVersion   int
+	ReportHistory   []ReportAmendment
+	Disputes        []DisputeRecord
+
+	// ProfessionalLicenses holds the result of the ProfessionalLicenseSearch, including any problems found.
+	ProfessionalLicenses []ProfessionalLicenseResult
+	// References holds the responses to the ReferenceCheck, including references who didn't respond.
+	References []ReferenceCheckResult
+	// CreditReport holds the result of the CreditReportSearch, if the candidate consented to one.
+	CreditReport *CreditReportSearchWorkflowResult
 }
 
 type BackgroundCheckWorkflowResult = BackgroundCheckState
@@ -32,18 +81,31 @@ type BackgroundCheckWorkflowResult = BackgroundCheckState
 // backgroundCheckWorkflow represents the state for a background check workflow execution.
 type backgroundCheckWorkflow struct {
 	BackgroundCheckState
-	checkID       string
-	searchFutures map[string]workflow.Future
-	logger        log.Logger
+	checkID                    string
+	searchFutures              map[string]workflow.Future
+	searchSelector             workflow.Selector
+	searchesStarted            bool
+	searchesDone               bool
+	adverseActionWaitingPeriod time.Duration
+	releaseReport              workflow.Settable
+	reportReleased             workflow.Future
+	pendingDisputes            int
+	logger                     log.Logger
 }
 
 // newBackgroundCheckWorkflow initializes a backgroundCheckWorkflow struct.
 func newBackgroundCheckWorkflow(ctx workflow.Context, state *BackgroundCheckState) *backgroundCheckWorkflow {
+	reportReleased, releaseReport := workflow.NewFuture(ctx)
+
 	return &backgroundCheckWorkflow{
-		BackgroundCheckState: *state,
-		checkID:              workflow.GetInfo(ctx).WorkflowExecution.RunID,
-		searchFutures:        make(map[string]workflow.Future),
-		logger:               workflow.GetLogger(ctx),
+		BackgroundCheckState:       *state,
+		checkID:                    workflow.GetInfo(ctx).WorkflowExecution.RunID,
+		searchFutures:              make(map[string]workflow.Future),
+		searchSelector:             workflow.NewSelector(ctx),
+		releaseReport:              releaseReport,
+		reportReleased:             reportReleased,
+		adverseActionWaitingPeriod: DefaultAdverseActionWaitingPeriod,
+		logger:                     workflow.GetLogger(ctx),
 	}
 }
 
@@ -57,9 +119,20 @@ func (w *backgroundCheckWorkflow) pushStatus(ctx workflow.Context, status string
 	)
 }
 
+// pushSearchesPending updates the SearchesPending search attribute with the number of searches that have not yet
+// completed, so that checks with outstanding searches can be found without querying each one.
+func (w *backgroundCheckWorkflow) pushSearchesPending(ctx workflow.Context) error {
+	return workflow.UpsertSearchAttributes(
+		ctx,
+		map[string]interface{}{
+			"SearchesPending": w.searchesPending(),
+		},
+	)
+}
+
 // waitForAccept waits for the candidate to accept or decline the background check.
 // If the candidate accepted, the response will include their personal information.
-func (w *backgroundCheckWorkflow) waitForAccept(ctx workflow.Context, email string) (*AcceptSubmission, error) {
+func (w *backgroundCheckWorkflow) waitForAccept(ctx workflow.Context, input *BackgroundCheckWorkflowInput) (*AcceptSubmission, error) {
 	var r AcceptSubmission
 
 	err := w.pushStatus(ctx, "pending_accept")
@@ -68,10 +141,13 @@ func (w *backgroundCheckWorkflow) waitForAccept(ctx workflow.Context, email stri
 	}
 
 	ctx = workflow.WithChildOptions(ctx, workflow.ChildWorkflowOptions{
-		WorkflowID: AcceptWorkflowID(email),
+		WorkflowID:          AcceptWorkflowID(input.Email),
+		WaitForCancellation: true,
 	})
 	consentWF := workflow.ExecuteChildWorkflow(ctx, Accept, AcceptWorkflowInput{
-		Email: email,
+		Email:                    input.Email,
+		Reminders:                input.AcceptReminders,
+		UnresponsiveNoticePeriod: input.UnresponsiveNoticePeriod,
 	})
 	err = consentWF.Get(ctx, &r)
 
@@ -85,7 +161,9 @@ func (w *backgroundCheckWorkflow) ssnTrace(ctx workflow.Context) (*SSNTraceWorkf
 	var r SSNTraceWorkflowResult
 
 	ssnTrace := workflow.ExecuteChildWorkflow(
-		ctx,
+		workflow.WithChildOptions(ctx, workflow.ChildWorkflowOptions{
+			WaitForCancellation: true,
+		}),
 		SSNTrace,
 		SSNTraceWorkflowInput{FullName: w.CandidateDetails.FullName, SSN: w.CandidateDetails.SSN},
 	)
@@ -98,57 +176,245 @@ func (w *backgroundCheckWorkflow) ssnTrace(ctx workflow.Context) (*SSNTraceWorkf
 	return &r, err
 }
 
-// sendDeclineEmail sends an email to the Hiring Manager informing them the candidate declined the background check.
+// verifyIdentity checks the candidate's details against the identity found by the SSN trace.
+// Identities with a low score are reviewed by a researcher, so this may take some time to complete.
+func (w *backgroundCheckWorkflow) verifyIdentity(ctx workflow.Context) (*IdentityVerificationWorkflowResult, error) {
+	var r IdentityVerificationWorkflowResult
+
+	identityVerification := workflow.ExecuteChildWorkflow(
+		workflow.WithChildOptions(ctx, workflow.ChildWorkflowOptions{
+			WaitForCancellation: true,
+		}),
+		IdentityVerification,
+		IdentityVerificationWorkflowInput{CandidateDetails: w.CandidateDetails, SSNTrace: *w.SSNTrace},
+	)
+
+	err := identityVerification.Get(ctx, &r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &r, nil
+}
+
+// sendDeclineEmail sends an email to the Hiring Manager informing them the candidate declined the background check,
+// or that the check expired because the candidate didn't respond in time.
 func (w *backgroundCheckWorkflow) sendDeclineEmail(ctx workflow.Context, email string) error {
-	w.pushStatus(ctx, "declined")
+	if w.Expired {
+		w.pushStatus(ctx, "expired")
+	} else {
+		w.pushStatus(ctx, "declined")
+	}
 
 	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
 		StartToCloseTimeout: time.Minute,
 	})
 
-	f := workflow.ExecuteActivity(ctx, a.SendDeclineEmail, activities.SendDeclineEmailInput{Email: w.Email})
+	f := workflow.ExecuteActivity(ctx, a.SendDeclineEmail, activities.SendDeclineEmailInput{Email: w.Email, Expired: w.Expired})
 	return f.Get(ctx, nil)
 }
 
 // sendReportEmail sends an email to the Hiring Manager with a link to the report page for the background check.
-func (w *backgroundCheckWorkflow) sendReportEmail(ctx workflow.Context, email string) error {
-	w.pushStatus(ctx, "completed")
-
+// If amended is true the email explains that the report has been updated since it was first sent.
+func (w *backgroundCheckWorkflow) sendReportEmail(ctx workflow.Context, email string, amended bool) error {
 	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
 		StartToCloseTimeout: time.Minute,
 	})
 
-	f := workflow.ExecuteActivity(ctx, a.SendReportEmail, activities.SendReportEmailInput{Email: w.Email, Token: TokenForWorkflow(ctx)})
+	input := activities.SendReportEmailInput{Email: w.Email, Token: TokenForWorkflow(ctx), Amended: amended}
+	if !amended {
+		input.Outstanding = w.outstandingSearches()
+	} else if latest := w.ReportHistory[len(w.ReportHistory)-1]; latest.Outcome == ReportOutcomeLateResult {
+		input.LateSearch = latest.SearchName
+	}
+
+	f := workflow.ExecuteActivity(ctx, a.SendReportEmail, input)
 	return f.Get(ctx, nil)
 }
 
+// sendReportAndAdjudicate sends the report to the Hiring Manager and then waits for their hiring decision.
+// The candidate may dispute findings in the report until the decision process is over.
+func (w *backgroundCheckWorkflow) sendReportAndAdjudicate(ctx workflow.Context) error {
+	err := w.sendReportEmail(ctx, activities.HiringManagerEmail, false)
+	if err != nil {
+		return err
+	}
+	w.ReportVersion = 1
+
+	w.handleDisputes(ctx)
+
+	err = w.adjudicate(ctx)
+	if err != nil {
+		return err
+	}
+
+	// The check's searches are ended along with it, so don't finish until the ones still running have reported.
+	err = w.waitForLateSearches(ctx)
+	if err != nil {
+		return err
+	}
+
+	return w.waitForDisputes(ctx)
+}
+
+// searchProgress returns the progress record for the named search.
+func (w *backgroundCheckWorkflow) searchProgress(name string) *SearchProgress {
+	for i := range w.Searches {
+		if w.Searches[i].Name == name {
+			return &w.Searches[i]
+		}
+	}
+	return nil
+}
+
+// searchesPending returns the number of searches that have not yet completed or failed.
+func (w *backgroundCheckWorkflow) searchesPending() int {
+	pending := 0
+	for _, search := range w.Searches {
+		if search.Status == SearchStatusPending || search.Status == SearchStatusRunning {
+			pending++
+		}
+	}
+	return pending
+}
+
 // startSearch starts a child workflow to perform one of the searches that make up the background check.
+// The search's progress is updated by the search selector as the child workflow starts and completes.
 func (w *backgroundCheckWorkflow) startSearch(ctx workflow.Context, name string, searchWorkflow interface{}, searchInputs ...interface{}) {
 	f := workflow.ExecuteChildWorkflow(
 		workflow.WithChildOptions(ctx, workflow.ChildWorkflowOptions{
-			WorkflowID: SearchWorkflowID(w.Email, name),
+			WorkflowID:          SearchWorkflowID(w.Email, name),
+			WaitForCancellation: true,
+			// Cancel rather than terminate searches if the background check is closed, so that any researchers
+			// assigned to them are released.
+			ParentClosePolicy: enums.PARENT_CLOSE_POLICY_REQUEST_CANCEL,
 		}),
 		searchWorkflow,
 		searchInputs...,
 	)
 	// Record the future for the search so we can collect the results later
 	w.searchFutures[name] = f
+	w.Searches = append(w.Searches, SearchProgress{
+		Name:        name,
+		Status:      SearchStatusPending,
+		ScheduledAt: workflow.Now(ctx),
+	})
+
+	w.searchSelector.AddFuture(f.GetChildWorkflowExecution(), func(execution workflow.Future) {
+		progress := w.searchProgress(name)
+		// If the search failed to start, the failure is recorded when its result is collected.
+		if execution.Get(ctx, nil) == nil && progress.Status == SearchStatusPending {
+			progress.Status = SearchStatusRunning
+			progress.StartedAt = workflow.Now(ctx)
+		}
+	})
+	w.searchSelector.AddFuture(f, func(f workflow.Future) {
+		w.collectSearch(ctx, name, f)
+	})
 }
 
-// waitForSearches waits for all of our searches to complete and collects the results.
-func (w *backgroundCheckWorkflow) waitForSearches(ctx workflow.Context) {
-	for name, f := range w.searchFutures {
-		var r interface{}
+// collectSearch records the result of a search once it has completed.
+func (w *backgroundCheckWorkflow) collectSearch(ctx workflow.Context, name string, f workflow.Future) {
+	progress := w.searchProgress(name)
+	progress.CompletedAt = workflow.Now(ctx)
+
+	defer func() {
+		err := w.pushSearchesPending(ctx)
+		if err != nil {
+			w.logger.Error("Unable to update searches pending", "error", err)
+		}
+	}()
+
+	var r interface{}
+
+	err := f.Get(ctx, &r)
+	if err != nil {
+		w.logger.Error("Search failed", "name", name, "error", err)
+		// Record an error for the search so we can include it in the report.
+		progress.Status = SearchStatusFailed
+		w.SearchErrors[name] = NewSearchError(err)
+		return
+	}
+	// Record the result of the search so we can use it in the report.
+	progress.Status = SearchStatusCompleted
+	w.SearchResults[name] = r
+
+	w.recordTypedResult(ctx, name, f)
+}
 
-		err := f.Get(ctx, &r)
+// recordTypedResult calls the search's OnResult hook, if it has one, to record a result in its typed form.
+func (w *backgroundCheckWorkflow) recordTypedResult(ctx workflow.Context, name string, f workflow.Future) {
+	search, err := LookupSearch(name)
+	if err == nil && search.OnResult != nil {
+		err = search.OnResult(ctx, &w.BackgroundCheckState, f)
 		if err != nil {
-			w.logger.Error("Search failed", "name", name, "error", err)
-			// Record an error for the search so we can include it in the report.
-			w.SearchErrors[name] = err.Error()
+			w.logger.Error("Unable to record search result", "name", name, "error", err)
+		}
+	}
+}
+
+// startSearches starts each of the searches in a package that applies to the candidate.
+// Searches that have already been started, for example before the check was upgraded, are not started again.
+func (w *backgroundCheckWorkflow) startSearches(ctx workflow.Context, pkg SearchPackage) {
+	w.searchesStarted = true
+
+	for _, search := range pkg.Searches {
+		if _, ok := w.searchFutures[search.Name]; ok {
+			continue
+		}
+		if search.Condition != nil && !search.Condition(&w.BackgroundCheckState) {
 			continue
 		}
-		// Record the result of the search so we can use it in the report.
-		w.SearchResults[name] = r
+		if search.Refusal != nil {
+			if reason := search.Refusal(&w.BackgroundCheckState); reason != "" {
+				w.logger.Info("Search refused", "name", search.Name, "reason", reason)
+				w.RefusedSearches[search.Name] = reason
+				continue
+			}
+		}
+		w.startSearch(ctx, search.Name, search.Workflow, search.Input(&w.BackgroundCheckState))
+	}
+
+	err := w.pushSearchesPending(ctx)
+	if err != nil {
+		w.logger.Error("Unable to update searches pending", "error", err)
+	}
+}
+
+// waitForSearches collects the result of each search as it completes, so that the status query shows the results
+// we have so far. Searches added by an upgrade while we are waiting are collected too.
+// If the SLA deadline passes and the report is released early, the searches that are still outstanding carry on
+// being collected in the background, and the report is amended as each completes.
+func (w *backgroundCheckWorkflow) waitForSearches(ctx workflow.Context) {
+	defer func() { w.searchesDone = true }()
+
+	released := false
+	w.searchSelector.AddFuture(w.reportReleased, func(f workflow.Future) {
+		released = true
+	})
+
+	for w.searchesPending() > 0 && !released {
+		w.searchSelector.Select(ctx)
+	}
+
+	if w.searchesPending() > 0 {
+		workflow.Go(ctx, func(ctx workflow.Context) {
+			for w.searchesPending() > 0 {
+				pending := w.outstandingSearches()
+				w.searchSelector.Select(ctx)
+
+				// The report may still be on its way to the Hiring Manager, so amend it once it has been sent.
+				err := workflow.Await(ctx, func() bool { return w.ReportVersion > 0 })
+				if err != nil {
+					return
+				}
+				for _, name := range pending {
+					if progress := w.searchProgress(name); progress.Status != SearchStatusPending && progress.Status != SearchStatusRunning {
+						w.amendReportWithLateResult(ctx, name)
+					}
+				}
+			}
+		})
 	}
 }
 
@@ -158,39 +424,62 @@ func (w *backgroundCheckWorkflow) waitForSearches(ctx workflow.Context) {
 // This is the main entry point of the application.
 // It accepts an email address as the input.
 // All other personal information for the Candidate is provided when they accept the Background Check.
-func BackgroundCheck(ctx workflow.Context, input *BackgroundCheckWorkflowInput) (*BackgroundCheckWorkflowResult, error) {
+func BackgroundCheck(ctx workflow.Context, input *BackgroundCheckWorkflowInput) (result *BackgroundCheckWorkflowResult, err error) {
 	w := newBackgroundCheckWorkflow(
 		ctx,
 		&BackgroundCheckState{
-			Email:         input.Email,
-			Tier:          input.Tier,
-			SearchResults: make(map[string]interface{}),
-			SearchErrors:  make(map[string]string),
+			Email:           input.Email,
+			Tier:            input.Tier,
+			SearchResults:   make(map[string]interface{}),
+			SearchErrors:    make(map[string]SearchError),
+			RefusedSearches: make(map[string]string),
 		},
 	)
 
+	// If the check is cancelled, whatever we were waiting on fails. Clean up and end the check as cancelled instead.
+	defer func() {
+		if ctx.Err() != nil {
+			err = w.cancel(ctx)
+		}
+	}()
+
+	if input.AdverseActionWaitingPeriod > 0 {
+		w.adverseActionWaitingPeriod = input.AdverseActionWaitingPeriod
+	}
+
+	// Reject unknown packages up front rather than running an unexpected set of searches.
+	pkg, err := LookupPackage(w.Tier)
+	if err != nil {
+		return &w.BackgroundCheckState, err
+	}
+
 	// The query returns the status of a background check and is used by the API to build the report at the end.
-	err := workflow.SetQueryHandler(ctx, BackgroundCheckStatusQuery, func() (BackgroundCheckState, error) {
+	err = workflow.SetQueryHandler(ctx, BackgroundCheckStatusQuery, func() (BackgroundCheckState, error) {
 		return w.BackgroundCheckState, nil
 	})
 	if err != nil {
 		return &w.BackgroundCheckState, err
 	}
 
+	// The Hiring Manager may upgrade the check to a different package while it is running.
+	w.handleUpgrades(ctx)
+
 	// Send the candidate an email asking them to accept or decline the background check.
-	response, err := w.waitForAccept(ctx, w.Email)
+	response, err := w.waitForAccept(ctx, input)
 	if err != nil {
 		return &w.BackgroundCheckState, err
 	}
 
 	w.Accepted = response.Accepted
+	w.Expired = response.Expired
 
-	// If the candidate declined the check, let the hiring manager know and then end the workflow.
+	// If the candidate declined the check, or didn't respond in time, let the hiring manager know and then end the workflow.
 	if !w.Accepted {
 		return &w.BackgroundCheckState, w.sendDeclineEmail(ctx, activities.HiringManagerEmail)
 	}
 
 	w.CandidateDetails = response.CandidateDetails
+	w.CreditConsent = response.CreditConsent
 
 	// Update our status search attribute. This is used by our API to filter the background check list if requested.
 	err = w.pushStatus(ctx, "running")
@@ -198,64 +487,62 @@ func BackgroundCheck(ctx workflow.Context, input *BackgroundCheckWorkflowInput)
 		return &w.BackgroundCheckState, err
 	}
 
+	// The turnaround SLA starts once the candidate has accepted. The package may have been upgraded by now.
+	sla := input.SLA
+	if sla == nil {
+		pkg, err = LookupPackage(w.Tier)
+		if err != nil {
+			return &w.BackgroundCheckState, err
+		}
+		sla = &pkg.SLA
+	}
+	w.enforceSLA(ctx, *sla)
+
 	// Run an SSN trace on the SSN the candidate provided when accepting the background check.
 	w.SSNTrace, err = w.ssnTrace(ctx)
 	if err != nil {
 		return &w.BackgroundCheckState, err
 	}
 
-	// If the SSN the candidate gave us was not valid then send a report email to the Hiring Manager and end the workflow.
+	// If the SSN the candidate gave us was not valid then send a report email to the Hiring Manager and wait for their decision.
 	// In this case all the searches are skipped.
 	if !w.SSNTrace.SSNIsValid {
-		return &w.BackgroundCheckState, w.sendReportEmail(ctx, activities.HiringManagerEmail)
+		return &w.BackgroundCheckState, w.sendReportAndAdjudicate(ctx)
 	}
 
-	// Start the main searches, these are run in parallel as they do not depend on each other.
+	// Check the candidate's details are consistent with the SSN trace before we search on them.
+	w.Identity, err = w.verifyIdentity(ctx)
+	if err != nil {
+		return &w.BackgroundCheckState, err
+	}
 
-	var primaryAddress string
-	if len(w.SSNTrace.KnownAddresses) > 0 {
-		primaryAddress = w.SSNTrace.KnownAddresses[0]
+	// If we could not verify the candidate's identity then the searches would not be reliable, so they are skipped
+	// and the Hiring Manager is sent the report as it stands.
+	if !w.Identity.Verified {
+		return &w.BackgroundCheckState, w.sendReportAndAdjudicate(ctx)
 	}
 
-	// We always run the FederalCriminalSearch
-	w.startSearch(
-		ctx,
-		"FederalCriminalSearch",
-		FederalCriminalSearch,
-		FederalCriminalSearchWorkflowInput{FullName: w.CandidateDetails.FullName, KnownAddresses: w.SSNTrace.KnownAddresses},
-	)
+	// Search under any other names the SSN is registered to as well, such as a maiden name.
+	w.CandidateDetails.Aliases = MergeAliases(w.CandidateDetails.FullName, w.CandidateDetails.Aliases, w.SSNTrace.NamesOnRecord)
 
-	// If the background check is on the full tier we run more searches
-	if w.Tier == "full" {
-		w.startSearch(
-			ctx,
-			"StateCriminalSearch",
-			StateCriminalSearch,
-			StateCriminalSearchWorkflowInput{FullName: w.CandidateDetails.FullName, KnownAddresses: w.SSNTrace.KnownAddresses},
-		)
-		w.startSearch(
-			ctx,
-			"MotorVehicleIncidentSearch",
-			MotorVehicleIncidentSearch,
-			MotorVehicleIncidentSearchWorkflowInput{FullName: w.CandidateDetails.FullName, Address: primaryAddress},
-		)
-
-		// Verify their employment if they provided an employer
-		if w.CandidateDetails.Employer != "" {
-			w.startSearch(
-				ctx,
-				"EmploymentVerification",
-				EmploymentVerification,
-				EmploymentVerificationWorkflowInput{CandidateDetails: w.CandidateDetails},
-			)
-		}
+	// Start the searches in the requested package, these are run in parallel as they do not depend on each other.
+	// The package may have changed since the check started if the Hiring Manager upgraded it.
+	pkg, err = LookupPackage(w.Tier)
+	if err != nil {
+		return &w.BackgroundCheckState, err
 	}
+	w.startSearches(ctx, pkg)
 
 	// Wait for all of our searches to complete.
 	w.waitForSearches(ctx)
 
-	// Send the report email to the Hiring Manager.
-	return &w.BackgroundCheckState, w.sendReportEmail(ctx, activities.HiringManagerEmail)
+	// Our searches are cancelled along with the check, so there is no report to send.
+	if ctx.Err() != nil {
+		return &w.BackgroundCheckState, ctx.Err()
+	}
+
+	// Send the report email to the Hiring Manager and wait for their decision.
+	return &w.BackgroundCheckState, w.sendReportAndAdjudicate(ctx)
 }
 
 // @@@SNIPEND
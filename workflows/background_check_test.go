@@ -1,12 +1,17 @@
 package workflows_test
 
 import (
+	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/temporalio/background-checks/activities"
 	"github.com/temporalio/background-checks/workflows"
 	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/temporal"
 	"go.temporal.io/sdk/testsuite"
 	"go.temporal.io/sdk/workflow"
 )
@@ -19,6 +24,7 @@ func TestBackgroundCheckWorkflowStandard(t *testing.T) {
 	env.RegisterWorkflow(workflows.Accept)
 	env.RegisterActivity(a.SendAcceptEmail)
 	env.RegisterWorkflow(workflows.SSNTrace)
+	env.RegisterWorkflow(workflows.IdentityVerification)
 	env.RegisterActivity(a.SSNTrace)
 	env.RegisterWorkflow(workflows.FederalCriminalSearch)
 	env.RegisterActivity(a.FederalCriminalSearch)
@@ -57,6 +63,7 @@ func TestBackgroundCheckWorkflowFull(t *testing.T) {
 	env.RegisterWorkflow(workflows.Accept)
 	env.RegisterActivity(a.SendAcceptEmail)
 	env.RegisterWorkflow(workflows.SSNTrace)
+	env.RegisterWorkflow(workflows.IdentityVerification)
 	env.RegisterActivity(a.SSNTrace)
 	env.RegisterWorkflow(workflows.FederalCriminalSearch)
 	env.RegisterActivity(a.FederalCriminalSearch)
@@ -91,3 +98,807 @@ func TestBackgroundCheckWorkflowFull(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Empty(t, result.SearchErrors)
 }
+
+func TestBackgroundCheckWorkflowUnknownPackage(t *testing.T) {
+	s := testsuite.WorkflowTestSuite{}
+	env := s.NewTestWorkflowEnvironment()
+
+	env.ExecuteWorkflow(workflows.BackgroundCheck, &workflows.BackgroundCheckWorkflowInput{Email: "john@example.com", Tier: "platinum"})
+
+	err := env.GetWorkflowError()
+	assert.ErrorContains(t, err, "unknown package: platinum")
+}
+
+func TestBackgroundCheckWorkflowAdverseAction(t *testing.T) {
+	s := testsuite.WorkflowTestSuite{}
+	env := s.NewTestWorkflowEnvironment()
+	a := activities.Activities{SMTPStub: true, HTTPStub: true}
+
+	env.RegisterWorkflow(workflows.Accept)
+	env.RegisterActivity(a.SendAcceptEmail)
+	env.RegisterWorkflow(workflows.SSNTrace)
+	env.RegisterWorkflow(workflows.IdentityVerification)
+	env.RegisterActivity(a.SSNTrace)
+	env.RegisterWorkflow(workflows.FederalCriminalSearch)
+	env.RegisterActivity(a.FederalCriminalSearch)
+	env.RegisterActivity(a.SendReportEmail)
+	env.RegisterActivity(a.SendPreAdverseActionEmail)
+	env.RegisterActivity(a.SendAdverseActionEmail)
+
+	details := workflows.CandidateDetails{
+		FullName: "John Smith",
+		SSN:      "111-11-1111",
+		DOB:      "1981-01-01",
+		Address:  "1 Chestnut Avenue",
+	}
+
+	env.SetOnChildWorkflowStartedListener(func(workflowInfo *workflow.Info, ctx workflow.Context, args converter.EncodedValues) {
+		if workflowInfo.WorkflowExecution.ID == workflows.AcceptWorkflowID("john@example.com") {
+			env.SignalWorkflowByID(
+				workflows.AcceptWorkflowID("john@example.com"),
+				workflows.AcceptSubmissionSignalName,
+				workflows.AcceptSubmissionSignal{Accepted: true, CandidateDetails: details},
+			)
+		}
+	})
+	env.RegisterDelayedCallback(
+		func() {
+			env.SignalWorkflow(
+				workflows.AdjudicationDecisionSignalName,
+				workflows.AdjudicationDecisionSignal{Decision: workflows.AdjudicationDecisionAdverse, Reason: "Criminal record"},
+			)
+		},
+		time.Hour,
+	)
+
+	env.ExecuteWorkflow(workflows.BackgroundCheck, &workflows.BackgroundCheckWorkflowInput{Email: "john@example.com", Tier: "standard"})
+
+	var result workflows.BackgroundCheckWorkflowResult
+	err := env.GetWorkflowResult(&result)
+	assert.NoError(t, err)
+	assert.Equal(t, workflows.AdjudicationDecisionAdverse, result.Adjudication.Decision)
+	assert.True(t, result.Adjudication.PreAdverseActionNoticeSent)
+	assert.True(t, result.Adjudication.AdverseActionNoticeSent)
+}
+
+func TestBackgroundCheckWorkflowAdverseActionWithdrawn(t *testing.T) {
+	s := testsuite.WorkflowTestSuite{}
+	env := s.NewTestWorkflowEnvironment()
+	a := activities.Activities{SMTPStub: true, HTTPStub: true}
+
+	env.RegisterWorkflow(workflows.Accept)
+	env.RegisterActivity(a.SendAcceptEmail)
+	env.RegisterWorkflow(workflows.SSNTrace)
+	env.RegisterWorkflow(workflows.IdentityVerification)
+	env.RegisterActivity(a.SSNTrace)
+	env.RegisterWorkflow(workflows.FederalCriminalSearch)
+	env.RegisterActivity(a.FederalCriminalSearch)
+	env.RegisterActivity(a.SendReportEmail)
+	env.RegisterActivity(a.SendPreAdverseActionEmail)
+	env.RegisterActivity(a.SendAdverseActionEmail)
+
+	details := workflows.CandidateDetails{
+		FullName: "John Smith",
+		SSN:      "111-11-1111",
+		DOB:      "1981-01-01",
+		Address:  "1 Chestnut Avenue",
+	}
+
+	env.SetOnChildWorkflowStartedListener(func(workflowInfo *workflow.Info, ctx workflow.Context, args converter.EncodedValues) {
+		if workflowInfo.WorkflowExecution.ID == workflows.AcceptWorkflowID("john@example.com") {
+			env.SignalWorkflowByID(
+				workflows.AcceptWorkflowID("john@example.com"),
+				workflows.AcceptSubmissionSignalName,
+				workflows.AcceptSubmissionSignal{Accepted: true, CandidateDetails: details},
+			)
+		}
+	})
+	env.RegisterDelayedCallback(
+		func() {
+			env.SignalWorkflow(
+				workflows.AdjudicationDecisionSignalName,
+				workflows.AdjudicationDecisionSignal{Decision: workflows.AdjudicationDecisionAdverse},
+			)
+		},
+		time.Hour,
+	)
+	env.RegisterDelayedCallback(
+		func() {
+			env.SignalWorkflow(
+				workflows.AdjudicationDecisionSignalName,
+				workflows.AdjudicationDecisionSignal{Decision: workflows.AdjudicationDecisionProceed},
+			)
+		},
+		time.Hour*24,
+	)
+
+	env.ExecuteWorkflow(workflows.BackgroundCheck, &workflows.BackgroundCheckWorkflowInput{Email: "john@example.com", Tier: "standard"})
+
+	var result workflows.BackgroundCheckWorkflowResult
+	err := env.GetWorkflowResult(&result)
+	assert.NoError(t, err)
+	assert.Equal(t, workflows.AdjudicationDecisionProceed, result.Adjudication.Decision)
+	assert.True(t, result.Adjudication.PreAdverseActionNoticeSent)
+	assert.True(t, result.Adjudication.AdverseActionWithdrawn)
+	assert.False(t, result.Adjudication.AdverseActionNoticeSent)
+}
+
+func TestBackgroundCheckWorkflowUpgrade(t *testing.T) {
+	s := testsuite.WorkflowTestSuite{}
+	env := s.NewTestWorkflowEnvironment()
+	a := activities.Activities{SMTPStub: true, HTTPStub: true}
+
+	env.RegisterWorkflow(workflows.Accept)
+	env.RegisterActivity(a.SendAcceptEmail)
+	env.RegisterWorkflow(workflows.SSNTrace)
+	env.RegisterWorkflow(workflows.IdentityVerification)
+	env.RegisterActivity(a.SSNTrace)
+	env.RegisterWorkflow(workflows.FederalCriminalSearch)
+	env.RegisterWorkflow(workflows.MotorVehicleIncidentSearch)
+	env.RegisterActivity(a.MotorVehicleIncidentSearch)
+	env.RegisterWorkflow(workflows.DrugScreen)
+	env.RegisterActivity(a.SendReportEmail)
+
+	env.OnWorkflow(workflows.DrugScreen, mock.Anything, mock.Anything).Return(
+		&workflows.DrugScreenWorkflowResult{OrderID: "1", Result: workflows.DrugScreenResultNegative}, nil,
+	)
+
+	// Keep the federal search running long enough for the upgrade to arrive.
+	env.OnWorkflow(workflows.FederalCriminalSearch, mock.Anything, mock.Anything).Return(
+		&workflows.FederalCriminalSearchWorkflowResult{}, nil,
+	).After(time.Hour)
+
+	details := workflows.CandidateDetails{
+		FullName: "John Smith",
+		SSN:      "111-11-1111",
+		DOB:      "1981-01-01",
+		Address:  "1 Chestnut Avenue",
+	}
+
+	env.SetOnChildWorkflowStartedListener(func(workflowInfo *workflow.Info, ctx workflow.Context, args converter.EncodedValues) {
+		if workflowInfo.WorkflowExecution.ID == workflows.AcceptWorkflowID("john@example.com") {
+			env.SignalWorkflowByID(
+				workflows.AcceptWorkflowID("john@example.com"),
+				workflows.AcceptSubmissionSignalName,
+				workflows.AcceptSubmissionSignal{Accepted: true, CandidateDetails: details},
+			)
+		}
+	})
+	env.RegisterDelayedCallback(
+		func() {
+			env.SignalWorkflow(workflows.UpgradeSignalName, workflows.UpgradeSignal{Tier: "driver"})
+		},
+		time.Minute*30,
+	)
+
+	env.ExecuteWorkflow(workflows.BackgroundCheck, &workflows.BackgroundCheckWorkflowInput{Email: "john@example.com", Tier: "standard"})
+
+	var result workflows.BackgroundCheckWorkflowResult
+	err := env.GetWorkflowResult(&result)
+	assert.NoError(t, err)
+	assert.Equal(t, "driver", result.Tier)
+	assert.Empty(t, result.SearchErrors)
+	assert.Contains(t, result.SearchResults, "FederalCriminalSearch")
+	assert.Contains(t, result.SearchResults, "MotorVehicleIncidentSearch")
+	assert.Contains(t, result.SearchResults, "DrugScreen")
+}
+
+func TestBackgroundCheckWorkflowSearchProgress(t *testing.T) {
+	s := testsuite.WorkflowTestSuite{}
+	env := s.NewTestWorkflowEnvironment()
+	a := activities.Activities{SMTPStub: true, HTTPStub: true}
+
+	env.RegisterWorkflow(workflows.Accept)
+	env.RegisterActivity(a.SendAcceptEmail)
+	env.RegisterWorkflow(workflows.SSNTrace)
+	env.RegisterWorkflow(workflows.IdentityVerification)
+	env.RegisterActivity(a.SSNTrace)
+	env.RegisterWorkflow(workflows.MotorVehicleIncidentSearch)
+	env.RegisterWorkflow(workflows.DrugScreen)
+	env.RegisterActivity(a.SendReportEmail)
+	// Mocked workflows are only reported as started once they return, so use a slow search to see it running.
+	env.RegisterWorkflowWithOptions(
+		func(ctx workflow.Context, input *workflows.FederalCriminalSearchWorkflowInput) (*workflows.FederalCriminalSearchWorkflowResult, error) {
+			return &workflows.FederalCriminalSearchWorkflowResult{}, workflow.Sleep(ctx, time.Hour)
+		},
+		workflow.RegisterOptions{Name: "FederalCriminalSearch"},
+	)
+
+	env.OnWorkflow(workflows.DrugScreen, mock.Anything, mock.Anything).Return(
+		&workflows.DrugScreenWorkflowResult{OrderID: "1", Result: workflows.DrugScreenResultNegative}, nil,
+	)
+	env.OnWorkflow(workflows.MotorVehicleIncidentSearch, mock.Anything, mock.Anything).Return(
+		nil, errors.New("DMV unavailable"),
+	)
+
+	details := workflows.CandidateDetails{
+		FullName: "John Smith",
+		SSN:      "111-11-1111",
+		DOB:      "1981-01-01",
+		Address:  "1 Chestnut Avenue",
+	}
+
+	env.SetOnChildWorkflowStartedListener(func(workflowInfo *workflow.Info, ctx workflow.Context, args converter.EncodedValues) {
+		if workflowInfo.WorkflowExecution.ID == workflows.AcceptWorkflowID("john@example.com") {
+			env.SignalWorkflowByID(
+				workflows.AcceptWorkflowID("john@example.com"),
+				workflows.AcceptSubmissionSignalName,
+				workflows.AcceptSubmissionSignal{Accepted: true, CandidateDetails: details},
+			)
+		}
+	})
+
+	statuses := func(searches []workflows.SearchProgress) map[string]string {
+		result := make(map[string]string)
+		for _, search := range searches {
+			result[search.Name] = search.Status
+		}
+		return result
+	}
+
+	// Results for the searches that have finished are available while the federal search is still running.
+	env.RegisterDelayedCallback(
+		func() {
+			v, err := env.QueryWorkflow(workflows.BackgroundCheckStatusQuery)
+			assert.NoError(t, err)
+
+			var state workflows.BackgroundCheckState
+			assert.NoError(t, v.Get(&state))
+
+			assert.Equal(t, map[string]string{
+				"FederalCriminalSearch":      workflows.SearchStatusRunning,
+				"MotorVehicleIncidentSearch": workflows.SearchStatusFailed,
+				"DrugScreen":                 workflows.SearchStatusCompleted,
+			}, statuses(state.Searches))
+			assert.Contains(t, state.SearchResults, "DrugScreen")
+			assert.NotContains(t, state.SearchResults, "FederalCriminalSearch")
+			assert.Contains(t, state.SearchErrors, "MotorVehicleIncidentSearch")
+		},
+		time.Minute*30,
+	)
+
+	env.ExecuteWorkflow(workflows.BackgroundCheck, &workflows.BackgroundCheckWorkflowInput{Email: "john@example.com", Tier: "driver"})
+
+	var result workflows.BackgroundCheckWorkflowResult
+	err := env.GetWorkflowResult(&result)
+	assert.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		"FederalCriminalSearch":      workflows.SearchStatusCompleted,
+		"MotorVehicleIncidentSearch": workflows.SearchStatusFailed,
+		"DrugScreen":                 workflows.SearchStatusCompleted,
+	}, statuses(result.Searches))
+	for _, search := range result.Searches {
+		assert.False(t, search.CompletedAt.Before(search.ScheduledAt), search.Name)
+	}
+	assert.Contains(t, result.SearchResults, "FederalCriminalSearch")
+}
+
+func TestBackgroundCheckWorkflowSLA(t *testing.T) {
+	s := testsuite.WorkflowTestSuite{}
+	env := s.NewTestWorkflowEnvironment()
+	a := activities.Activities{SMTPStub: true, HTTPStub: true}
+
+	env.RegisterWorkflow(workflows.Accept)
+	env.RegisterActivity(a.SendAcceptEmail)
+	env.RegisterWorkflow(workflows.SSNTrace)
+	env.RegisterWorkflow(workflows.IdentityVerification)
+	env.RegisterActivity(a.SSNTrace)
+	env.RegisterWorkflow(workflows.MotorVehicleIncidentSearch)
+	env.RegisterWorkflow(workflows.DrugScreen)
+	env.RegisterActivity(a.SendReportEmail)
+	env.RegisterActivity(a.SendSLAWarningEmail)
+	env.RegisterWorkflowWithOptions(
+		func(ctx workflow.Context, input *workflows.FederalCriminalSearchWorkflowInput) (*workflows.FederalCriminalSearchWorkflowResult, error) {
+			return &workflows.FederalCriminalSearchWorkflowResult{}, workflow.Sleep(ctx, time.Hour*3)
+		},
+		workflow.RegisterOptions{Name: "FederalCriminalSearch"},
+	)
+
+	env.OnWorkflow(workflows.DrugScreen, mock.Anything, mock.Anything).Return(
+		&workflows.DrugScreenWorkflowResult{OrderID: "1", Result: workflows.DrugScreenResultNegative}, nil,
+	)
+	env.OnWorkflow(workflows.MotorVehicleIncidentSearch, mock.Anything, mock.Anything).Return(
+		&workflows.MotorVehicleIncidentSearchWorkflowResult{}, nil,
+	)
+
+	var warnings []activities.SendSLAWarningEmailInput
+	env.OnActivity(a.SendSLAWarningEmail, mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, input *activities.SendSLAWarningEmailInput) (*activities.SendSLAWarningEmailResult, error) {
+			warnings = append(warnings, *input)
+			return &activities.SendSLAWarningEmailResult{}, nil
+		},
+	)
+
+	var reports []activities.SendReportEmailInput
+	env.OnActivity(a.SendReportEmail, mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, input *activities.SendReportEmailInput) (*activities.SendReportEmailResult, error) {
+			reports = append(reports, *input)
+			return &activities.SendReportEmailResult{}, nil
+		},
+	)
+
+	details := workflows.CandidateDetails{
+		FullName: "John Smith",
+		SSN:      "111-11-1111",
+		DOB:      "1981-01-01",
+		Address:  "1 Chestnut Avenue",
+	}
+
+	env.SetOnChildWorkflowStartedListener(func(workflowInfo *workflow.Info, ctx workflow.Context, args converter.EncodedValues) {
+		if workflowInfo.WorkflowExecution.ID == workflows.AcceptWorkflowID("john@example.com") {
+			env.SignalWorkflowByID(
+				workflows.AcceptWorkflowID("john@example.com"),
+				workflows.AcceptSubmissionSignalName,
+				workflows.AcceptSubmissionSignal{Accepted: true, CandidateDetails: details},
+			)
+		}
+	})
+
+	// The check is marked as delayed once it passes the warning point, but the report is held until the deadline.
+	env.RegisterDelayedCallback(
+		func() {
+			v, err := env.QueryWorkflow(workflows.BackgroundCheckStatusQuery)
+			assert.NoError(t, err)
+
+			var state workflows.BackgroundCheckState
+			assert.NoError(t, v.Get(&state))
+
+			assert.True(t, state.Delayed)
+			assert.Equal(t, 0, state.ReportVersion)
+			assert.Empty(t, reports)
+		},
+		time.Minute*90,
+	)
+
+	env.ExecuteWorkflow(workflows.BackgroundCheck, &workflows.BackgroundCheckWorkflowInput{
+		Email: "john@example.com",
+		Tier:  "driver",
+		SLA: &workflows.TurnaroundSLA{
+			Warnings:             []time.Duration{time.Hour},
+			Deadline:             time.Hour * 2,
+			ReleasePartialReport: true,
+		},
+	})
+
+	var result workflows.BackgroundCheckWorkflowResult
+	err := env.GetWorkflowResult(&result)
+	assert.NoError(t, err)
+
+	assert.True(t, result.Delayed)
+	if assert.Len(t, warnings, 1) {
+		assert.Equal(t, []string{"FederalCriminalSearch"}, warnings[0].Outstanding)
+	}
+	// The report was released at the deadline, before the federal search completed.
+	if assert.NotEmpty(t, reports) {
+		assert.Equal(t, []string{"FederalCriminalSearch"}, reports[0].Outstanding)
+	}
+	// The federal search is still collected once it completes, and the report is amended with its result.
+	assert.Contains(t, result.SearchResults, "FederalCriminalSearch")
+	if assert.Len(t, reports, 2) {
+		assert.True(t, reports[1].Amended)
+		assert.Equal(t, "FederalCriminalSearch", reports[1].LateSearch)
+	}
+	assert.Equal(t, 2, result.ReportVersion)
+	if assert.Len(t, result.ReportHistory, 1) {
+		assert.Equal(t, workflows.ReportOutcomeLateResult, result.ReportHistory[0].Outcome)
+	}
+}
+
+func TestBackgroundCheckWorkflowCancelled(t *testing.T) {
+	s := testsuite.WorkflowTestSuite{}
+	env := s.NewTestWorkflowEnvironment()
+	a := activities.Activities{SMTPStub: true, HTTPStub: true}
+
+	env.RegisterWorkflow(workflows.Accept)
+	env.RegisterActivity(a.SendAcceptEmail)
+	env.RegisterWorkflow(workflows.SSNTrace)
+	env.RegisterWorkflow(workflows.IdentityVerification)
+	env.RegisterActivity(a.SSNTrace)
+	env.RegisterWorkflow(workflows.MotorVehicleIncidentSearch)
+	env.RegisterWorkflow(workflows.DrugScreen)
+	env.RegisterActivity(a.SendReportEmail)
+	env.RegisterActivity(a.SendCancellationEmail)
+	env.RegisterWorkflowWithOptions(
+		func(ctx workflow.Context, input *workflows.FederalCriminalSearchWorkflowInput) (*workflows.FederalCriminalSearchWorkflowResult, error) {
+			return &workflows.FederalCriminalSearchWorkflowResult{}, workflow.Sleep(ctx, time.Hour*3)
+		},
+		workflow.RegisterOptions{Name: "FederalCriminalSearch"},
+	)
+
+	env.OnWorkflow(workflows.DrugScreen, mock.Anything, mock.Anything).Return(
+		&workflows.DrugScreenWorkflowResult{OrderID: "1", Result: workflows.DrugScreenResultNegative}, nil,
+	)
+	env.OnWorkflow(workflows.MotorVehicleIncidentSearch, mock.Anything, mock.Anything).Return(
+		&workflows.MotorVehicleIncidentSearchWorkflowResult{}, nil,
+	)
+
+	var notices []activities.SendCancellationEmailInput
+	env.OnActivity(a.SendCancellationEmail, mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, input *activities.SendCancellationEmailInput) (*activities.SendCancellationEmailResult, error) {
+			notices = append(notices, *input)
+			return &activities.SendCancellationEmailResult{}, nil
+		},
+	)
+
+	details := workflows.CandidateDetails{
+		FullName: "John Smith",
+		SSN:      "111-11-1111",
+		DOB:      "1981-01-01",
+		Address:  "1 Chestnut Avenue",
+	}
+
+	env.SetOnChildWorkflowStartedListener(func(workflowInfo *workflow.Info, ctx workflow.Context, args converter.EncodedValues) {
+		if workflowInfo.WorkflowExecution.ID == workflows.AcceptWorkflowID("john@example.com") {
+			env.SignalWorkflowByID(
+				workflows.AcceptWorkflowID("john@example.com"),
+				workflows.AcceptSubmissionSignalName,
+				workflows.AcceptSubmissionSignal{Accepted: true, CandidateDetails: details},
+			)
+		}
+	})
+
+	// Cancel the check while the federal search is still running.
+	env.RegisterDelayedCallback(
+		func() {
+			env.SignalWorkflow(workflows.CancellationSignalName, workflows.CancellationSignal{
+				CancelledBy: "hiring@company.local",
+				Reason:      "Position filled",
+			})
+			env.CancelWorkflow()
+		},
+		time.Minute*30,
+	)
+
+	env.ExecuteWorkflow(workflows.BackgroundCheck, &workflows.BackgroundCheckWorkflowInput{Email: "john@example.com", Tier: "driver"})
+
+	assert.True(t, env.IsWorkflowCompleted())
+	assert.True(t, temporal.IsCanceledError(env.GetWorkflowError()))
+
+	v, err := env.QueryWorkflow(workflows.BackgroundCheckStatusQuery)
+	assert.NoError(t, err)
+
+	var state workflows.BackgroundCheckState
+	assert.NoError(t, v.Get(&state))
+
+	if assert.NotNil(t, state.Cancellation) {
+		assert.Equal(t, "hiring@company.local", state.Cancellation.CancelledBy)
+		assert.Equal(t, "Position filled", state.Cancellation.Reason)
+	}
+	assert.Equal(t, 0, state.ReportVersion)
+	assert.Equal(t, workflows.SearchErrorCanceled, state.SearchErrors["FederalCriminalSearch"].Type)
+	for _, search := range state.Searches {
+		assert.NotEqual(t, workflows.SearchStatusRunning, search.Status, search.Name)
+	}
+
+	if assert.Len(t, notices, 1) {
+		assert.Equal(t, activities.SendCancellationEmailInput{
+			Email:       "john@example.com",
+			CancelledBy: "hiring@company.local",
+			Reason:      "Position filled",
+		}, notices[0])
+	}
+}
+
+func TestBackgroundCheckWorkflowProfessionalLicenses(t *testing.T) {
+	s := testsuite.WorkflowTestSuite{}
+	env := s.NewTestWorkflowEnvironment()
+	a := activities.Activities{SMTPStub: true, HTTPStub: true}
+
+	env.RegisterWorkflow(workflows.Accept)
+	env.RegisterActivity(a.SendAcceptEmail)
+	env.RegisterWorkflow(workflows.SSNTrace)
+	env.RegisterWorkflow(workflows.IdentityVerification)
+	env.RegisterActivity(a.SSNTrace)
+	env.RegisterWorkflow(workflows.FederalCriminalSearch)
+	env.RegisterActivity(a.FederalCriminalSearch)
+	env.RegisterWorkflow(workflows.StateCriminalSearch)
+	env.RegisterActivity(a.StateCriminalSearch)
+	env.RegisterWorkflow(workflows.ProfessionalLicenseSearch)
+	env.RegisterActivity(a.SendReportEmail)
+
+	licenses := []workflows.ProfessionalLicenseResult{
+		{Type: "RN", Number: "654321", State: "CA", Status: "active", Expires: "2001-01-31", Problems: []string{workflows.LicenseProblemExpired}},
+	}
+	env.OnWorkflow(workflows.ProfessionalLicenseSearch, mock.Anything, mock.Anything).Return(
+		&workflows.ProfessionalLicenseSearchWorkflowResult{Licenses: licenses}, nil,
+	)
+
+	details := workflows.CandidateDetails{
+		FullName: "John Smith",
+		SSN:      "111-11-1111",
+		DOB:      "1981-01-01",
+		Address:  "1 Chestnut Avenue",
+		Licenses: []workflows.LicenseRecord{{Type: "RN", Number: "654321", State: "CA"}},
+	}
+
+	env.SetOnChildWorkflowStartedListener(func(workflowInfo *workflow.Info, ctx workflow.Context, args converter.EncodedValues) {
+		if workflowInfo.WorkflowExecution.ID == workflows.AcceptWorkflowID("john@example.com") {
+			env.SignalWorkflowByID(
+				workflows.AcceptWorkflowID("john@example.com"),
+				workflows.AcceptSubmissionSignalName,
+				workflows.AcceptSubmissionSignal{Accepted: true, CandidateDetails: details},
+			)
+		}
+	})
+
+	env.ExecuteWorkflow(workflows.BackgroundCheck, &workflows.BackgroundCheckWorkflowInput{Email: "john@example.com", Tier: "professional"})
+
+	var result workflows.BackgroundCheckWorkflowResult
+	err := env.GetWorkflowResult(&result)
+	assert.NoError(t, err)
+	assert.Empty(t, result.SearchErrors)
+	assert.Contains(t, result.SearchResults, "ProfessionalLicenseSearch")
+	assert.Equal(t, licenses, result.ProfessionalLicenses)
+}
+
+func TestBackgroundCheckWorkflowDisputeAmendsTypedResult(t *testing.T) {
+	s := testsuite.WorkflowTestSuite{}
+	env := s.NewTestWorkflowEnvironment()
+	a := activities.Activities{SMTPStub: true, HTTPStub: true}
+
+	env.RegisterWorkflow(workflows.Accept)
+	env.RegisterActivity(a.SendAcceptEmail)
+	env.RegisterWorkflow(workflows.SSNTrace)
+	env.RegisterWorkflow(workflows.IdentityVerification)
+	env.RegisterActivity(a.SSNTrace)
+	env.RegisterWorkflow(workflows.FederalCriminalSearch)
+	env.RegisterActivity(a.FederalCriminalSearch)
+	env.RegisterWorkflow(workflows.StateCriminalSearch)
+	env.RegisterActivity(a.StateCriminalSearch)
+	env.RegisterWorkflow(workflows.ProfessionalLicenseSearch)
+	env.RegisterWorkflow(workflows.Dispute)
+	env.RegisterActivity(a.SendReportEmail)
+	env.RegisterActivity(a.SendPreAdverseActionEmail)
+	env.RegisterActivity(a.SendAdverseActionEmail)
+	env.RegisterActivity(a.SendDisputeResultEmail)
+
+	license := workflows.ProfessionalLicenseResult{Type: "RN", Number: "654321", State: "CA", Status: "active", Expires: "2001-01-31"}
+	expired := license
+	expired.Problems = []string{workflows.LicenseProblemExpired}
+
+	env.OnWorkflow(workflows.ProfessionalLicenseSearch, mock.Anything, mock.Anything).Return(
+		&workflows.ProfessionalLicenseSearchWorkflowResult{Licenses: []workflows.ProfessionalLicenseResult{expired}}, nil,
+	)
+	// The licensing board has since corrected the record.
+	env.OnWorkflow(workflows.Dispute, mock.Anything, mock.Anything).Return(
+		&workflows.DisputeWorkflowResult{
+			Outcome: workflows.DisputeOutcomeAmended,
+			Result:  workflows.ProfessionalLicenseSearchWorkflowResult{Licenses: []workflows.ProfessionalLicenseResult{license}},
+		}, nil,
+	)
+
+	details := workflows.CandidateDetails{
+		FullName: "John Smith",
+		SSN:      "111-11-1111",
+		DOB:      "1981-01-01",
+		Address:  "1 Chestnut Avenue",
+		Licenses: []workflows.LicenseRecord{{Type: "RN", Number: "654321", State: "CA"}},
+	}
+
+	env.SetOnChildWorkflowStartedListener(func(workflowInfo *workflow.Info, ctx workflow.Context, args converter.EncodedValues) {
+		if workflowInfo.WorkflowExecution.ID == workflows.AcceptWorkflowID("john@example.com") {
+			env.SignalWorkflowByID(
+				workflows.AcceptWorkflowID("john@example.com"),
+				workflows.AcceptSubmissionSignalName,
+				workflows.AcceptSubmissionSignal{Accepted: true, CandidateDetails: details},
+			)
+		}
+	})
+	env.RegisterDelayedCallback(
+		func() {
+			env.SignalWorkflow(
+				workflows.AdjudicationDecisionSignalName,
+				workflows.AdjudicationDecisionSignal{Decision: workflows.AdjudicationDecisionAdverse, Reason: "Expired license"},
+			)
+		},
+		time.Hour,
+	)
+	env.RegisterDelayedCallback(
+		func() {
+			env.SignalWorkflow(
+				workflows.DisputeSubmissionSignalName,
+				workflows.DisputeSubmissionSignal{SearchName: "ProfessionalLicenseSearch", Finding: workflows.LicenseProblemExpired},
+			)
+		},
+		time.Hour*2,
+	)
+
+	env.ExecuteWorkflow(workflows.BackgroundCheck, &workflows.BackgroundCheckWorkflowInput{Email: "john@example.com", Tier: "professional"})
+
+	var result workflows.BackgroundCheckWorkflowResult
+	err := env.GetWorkflowResult(&result)
+	assert.NoError(t, err)
+	assert.Len(t, result.Disputes, 1)
+	assert.Equal(t, workflows.DisputeOutcomeAmended, result.Disputes[0].Status)
+	assert.Equal(t, 2, result.ReportVersion)
+	// The typed result is amended along with the one in the report.
+	assert.Equal(t, []workflows.ProfessionalLicenseResult{license}, result.ProfessionalLicenses)
+}
+
+func TestBackgroundCheckWorkflowCreditReport(t *testing.T) {
+	for _, consent := range []bool{true, false} {
+		s := testsuite.WorkflowTestSuite{}
+		env := s.NewTestWorkflowEnvironment()
+		a := activities.Activities{SMTPStub: true, HTTPStub: true}
+
+		env.RegisterWorkflow(workflows.Accept)
+		env.RegisterActivity(a.SendAcceptEmail)
+		env.RegisterWorkflow(workflows.SSNTrace)
+		env.RegisterWorkflow(workflows.IdentityVerification)
+		env.RegisterActivity(a.SSNTrace)
+		env.RegisterWorkflow(workflows.FederalCriminalSearch)
+		env.RegisterActivity(a.FederalCriminalSearch)
+		env.RegisterWorkflow(workflows.StateCriminalSearch)
+		env.RegisterActivity(a.StateCriminalSearch)
+		env.RegisterWorkflow(workflows.CountyCriminalSearches)
+		env.RegisterWorkflow(workflows.CreditReportSearch)
+		env.RegisterWorkflow(workflows.WatchlistSearch)
+		env.RegisterActivity(a.SendReportEmail)
+
+		env.OnWorkflow(workflows.WatchlistSearch, mock.Anything, mock.Anything).Return(
+			&workflows.WatchlistSearchWorkflowResult{}, nil,
+		)
+		env.OnWorkflow(workflows.CreditReportSearch, mock.Anything, mock.Anything).Return(
+			&workflows.CreditReportSearchWorkflowResult{
+				Bankruptcies: []activities.Bankruptcy{{Chapter: 7, Filed: "2016-11-03", Status: "discharged"}},
+			}, nil,
+		)
+
+		details := workflows.CandidateDetails{
+			FullName: "John Smith",
+			SSN:      "111-11-1111",
+			DOB:      "1981-01-01",
+		}
+
+		env.SetOnChildWorkflowStartedListener(func(workflowInfo *workflow.Info, ctx workflow.Context, args converter.EncodedValues) {
+			if workflowInfo.WorkflowExecution.ID == workflows.AcceptWorkflowID("john@example.com") {
+				env.SignalWorkflowByID(
+					workflows.AcceptWorkflowID("john@example.com"),
+					workflows.AcceptSubmissionSignalName,
+					workflows.AcceptSubmissionSignal{Accepted: true, CandidateDetails: details, CreditConsent: consent},
+				)
+			}
+		})
+
+		env.ExecuteWorkflow(workflows.BackgroundCheck, &workflows.BackgroundCheckWorkflowInput{Email: "john@example.com", Tier: "finance"})
+
+		var result workflows.BackgroundCheckWorkflowResult
+		err := env.GetWorkflowResult(&result)
+		assert.NoError(t, err)
+		assert.Empty(t, result.SearchErrors)
+
+		if consent {
+			assert.Empty(t, result.RefusedSearches)
+			assert.Contains(t, result.SearchResults, "CreditReportSearch")
+			assert.Len(t, result.CreditReport.Bankruptcies, 1)
+		} else {
+			// Without consent the search is never started, and the report says why.
+			assert.Equal(t, map[string]string{"CreditReportSearch": workflows.CreditConsentMissingReason}, result.RefusedSearches)
+			assert.NotContains(t, result.SearchResults, "CreditReportSearch")
+			assert.Nil(t, result.CreditReport)
+		}
+	}
+}
+
+func TestBackgroundCheckWorkflowIdentityNotVerified(t *testing.T) {
+	s := testsuite.WorkflowTestSuite{}
+	env := s.NewTestWorkflowEnvironment()
+	a := activities.Activities{SMTPStub: true, HTTPStub: true}
+
+	env.RegisterWorkflow(workflows.Accept)
+	env.RegisterActivity(a.SendAcceptEmail)
+	env.RegisterWorkflow(workflows.SSNTrace)
+	env.RegisterWorkflow(workflows.IdentityVerification)
+	env.RegisterActivity(a.SSNTrace)
+	env.RegisterWorkflow(workflows.FederalCriminalSearch)
+	env.RegisterActivity(a.SendReportEmail)
+
+	env.OnWorkflow(workflows.IdentityVerification, mock.Anything, mock.Anything).Return(
+		&workflows.IdentityVerificationWorkflowResult{
+			Score:    0.1,
+			Flags:    []string{workflows.IdentityFlagNameMismatch, workflows.IdentityFlagSSNIssuedBeforeBirth},
+			Verified: false,
+			Source:   workflows.IdentitySourceResearcher,
+		}, nil,
+	)
+
+	details := workflows.CandidateDetails{
+		FullName: "John Smith",
+		SSN:      "333-33-3333",
+		DOB:      "1981-01-01",
+	}
+
+	env.SetOnChildWorkflowStartedListener(func(workflowInfo *workflow.Info, ctx workflow.Context, args converter.EncodedValues) {
+		if workflowInfo.WorkflowExecution.ID == workflows.AcceptWorkflowID("john@example.com") {
+			env.SignalWorkflowByID(
+				workflows.AcceptWorkflowID("john@example.com"),
+				workflows.AcceptSubmissionSignalName,
+				workflows.AcceptSubmissionSignal{Accepted: true, CandidateDetails: details},
+			)
+		}
+	})
+
+	env.ExecuteWorkflow(workflows.BackgroundCheck, &workflows.BackgroundCheckWorkflowInput{Email: "john@example.com", Tier: "standard"})
+
+	var result workflows.BackgroundCheckWorkflowResult
+	err := env.GetWorkflowResult(&result)
+	assert.NoError(t, err)
+
+	// None of the searches are run for an identity we couldn't verify.
+	assert.False(t, result.Identity.Verified)
+	assert.Empty(t, result.SearchResults)
+	assert.Empty(t, result.SearchErrors)
+}
+
+func TestBackgroundCheckWorkflowAcceptSchedule(t *testing.T) {
+	s := testsuite.WorkflowTestSuite{}
+	env := s.NewTestWorkflowEnvironment()
+	a := activities.Activities{SMTPStub: true}
+
+	env.RegisterWorkflow(workflows.Accept)
+	env.RegisterActivity(a.SendDeclineEmail)
+
+	var accept workflows.AcceptWorkflowInput
+	env.OnWorkflow(workflows.Accept, mock.Anything, mock.Anything).Return(
+		func(ctx workflow.Context, input *workflows.AcceptWorkflowInput) (*workflows.AcceptWorkflowResult, error) {
+			accept = *input
+			return &workflows.AcceptWorkflowResult{Expired: true}, nil
+		},
+	)
+
+	env.ExecuteWorkflow(workflows.BackgroundCheck, &workflows.BackgroundCheckWorkflowInput{
+		Email:                    "john@example.com",
+		Tier:                     "standard",
+		AcceptReminders:          []time.Duration{time.Hour * 24},
+		UnresponsiveNoticePeriod: time.Hour * 48,
+	})
+
+	var result workflows.BackgroundCheckWorkflowResult
+	err := env.GetWorkflowResult(&result)
+	assert.NoError(t, err)
+	assert.True(t, result.Expired)
+
+	// The check's reminder schedule is passed on to the candidate's acceptance.
+	assert.Equal(t, []time.Duration{time.Hour * 24}, accept.Reminders)
+	assert.Equal(t, time.Hour*48, accept.UnresponsiveNoticePeriod)
+}
+
+func TestBackgroundCheckWorkflowCancelledWithoutSignal(t *testing.T) {
+	s := testsuite.WorkflowTestSuite{}
+	env := s.NewTestWorkflowEnvironment()
+	a := activities.Activities{SMTPStub: true}
+
+	env.RegisterWorkflow(workflows.Accept)
+	env.RegisterActivity(a.SendAcceptEmail)
+	env.RegisterActivity(a.SendAcceptReminderEmail)
+
+	var notices []activities.SendCancellationEmailInput
+	env.OnActivity(a.SendCancellationEmail, mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, input *activities.SendCancellationEmailInput) (*activities.SendCancellationEmailResult, error) {
+			notices = append(notices, *input)
+			return &activities.SendCancellationEmailResult{}, nil
+		},
+	)
+
+	// Cancel the check while waiting for the candidate, without saying who cancelled it.
+	env.RegisterDelayedCallback(env.CancelWorkflow, time.Hour)
+
+	env.ExecuteWorkflow(workflows.BackgroundCheck, &workflows.BackgroundCheckWorkflowInput{Email: "john@example.com", Tier: "standard"})
+
+	assert.True(t, env.IsWorkflowCompleted())
+	assert.True(t, temporal.IsCanceledError(env.GetWorkflowError()))
+
+	v, err := env.QueryWorkflow(workflows.BackgroundCheckStatusQuery)
+	assert.NoError(t, err)
+
+	var state workflows.BackgroundCheckState
+	assert.NoError(t, v.Get(&state))
+
+	if assert.NotNil(t, state.Cancellation) {
+		assert.Equal(t, workflows.CancelledByUnknown, state.Cancellation.CancelledBy)
+	}
+	if assert.Len(t, notices, 1) {
+		assert.Equal(t, workflows.CancelledByUnknown, notices[0].CancelledBy)
+	}
+}
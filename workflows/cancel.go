@@ -0,0 +1,101 @@
+package workflows
+
+import (
+	"time"
+
+	"github.com/temporalio/background-checks/activities"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+const (
+	CancellationSignalName = "cancellation"
+	// CancelledByUnknown is recorded when a check is cancelled without saying who cancelled it, such as from the
+	// Temporal UI or CLI.
+	CancelledByUnknown = "unknown"
+)
+
+// CancellationSignal is sent just before a background check is cancelled, to record who cancelled it and why.
+type CancellationSignal struct {
+	CancelledBy string
+	Reason      string
+}
+
+// Cancellation records who cancelled a background check, why, and when.
+type Cancellation struct {
+	CancelledBy string
+	Reason      string
+	CancelledAt time.Time
+}
+
+// sendCancellationEmail lets the Hiring Manager and the candidate know that the check has been cancelled.
+func (w *backgroundCheckWorkflow) sendCancellationEmail(ctx workflow.Context) error {
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: time.Minute,
+	})
+
+	f := workflow.ExecuteActivity(ctx, a.SendCancellationEmail, activities.SendCancellationEmailInput{
+		Email:       w.Email,
+		CancelledBy: w.Cancellation.CancelledBy,
+		Reason:      w.Cancellation.Reason,
+	})
+	return f.Get(ctx, nil)
+}
+
+// cancel cleans up once the background check has been cancelled, and returns the error that ends the workflow as
+// cancelled. Each child workflow was asked to cancel along with us, which revokes the tokens they handed out and
+// releases any researchers they were assigned, so we wait for them to finish before recording the cancellation and
+// sending the notices.
+func (w *backgroundCheckWorkflow) cancel(ctx workflow.Context) error {
+	// Our context is cancelled, so anything we still need to do uses a disconnected one.
+	dctx, _ := workflow.NewDisconnectedContext(ctx)
+
+	var signal CancellationSignal
+	workflow.GetSignalChannel(ctx, CancellationSignalName).ReceiveAsync(&signal)
+	if signal.CancelledBy == "" {
+		signal.CancelledBy = CancelledByUnknown
+	}
+	w.Cancellation = &Cancellation{
+		CancelledBy: signal.CancelledBy,
+		Reason:      signal.Reason,
+		CancelledAt: workflow.Now(ctx),
+	}
+	w.logger.Info("Background check cancelled", "cancelledBy", signal.CancelledBy, "reason", signal.Reason)
+
+	for _, search := range w.Searches {
+		err := w.searchFutures[search.Name].Get(dctx, nil)
+		if err != nil {
+			w.logger.Info("Search cancelled", "name", search.Name, "error", err)
+		}
+	}
+	err := workflow.Await(dctx, func() bool { return w.pendingDisputes == 0 })
+	if err != nil {
+		w.logger.Error("Unable to wait for disputes", "error", err)
+	}
+
+	for i := range w.Searches {
+		search := &w.Searches[i]
+		if search.Status != SearchStatusPending && search.Status != SearchStatusRunning {
+			continue
+		}
+		search.Status = SearchStatusFailed
+		search.CompletedAt = workflow.Now(ctx)
+		w.SearchErrors[search.Name] = SearchError{Type: SearchErrorCanceled, Message: "the background check was cancelled"}
+	}
+
+	err = w.pushStatus(dctx, "cancelled")
+	if err != nil {
+		w.logger.Error("Unable to mark check as cancelled", "error", err)
+	}
+	err = w.pushSearchesPending(dctx)
+	if err != nil {
+		w.logger.Error("Unable to update searches pending", "error", err)
+	}
+
+	err = w.sendCancellationEmail(dctx)
+	if err != nil {
+		w.logger.Error("Unable to send cancellation email", "error", err)
+	}
+
+	return temporal.NewCanceledError(*w.Cancellation)
+}
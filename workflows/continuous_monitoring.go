@@ -0,0 +1,276 @@
+package workflows
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/temporalio/background-checks/activities"
+	"go.temporal.io/sdk/workflow"
+)
+
+const (
+	ContinuousMonitoringStatusQuery    = "continuous-monitoring-status"
+	ContinuousMonitoringStopSignalName = "continuous-monitoring-stop"
+	DefaultMonitoringInterval          = time.Hour * 24 * 30
+)
+
+// monitoredSearches are the searches that are re-run on each monitoring cycle.
+var monitoredSearches = []string{
+	"FederalCriminalSearch",
+	"StateCriminalSearch",
+}
+
+type ContinuousMonitoringWorkflowInput struct {
+	Email            string
+	CandidateDetails CandidateDetails
+	SSNTrace         SSNTraceWorkflowResult
+	Interval         time.Duration
+	Cycle            int
+	// KnownFindings holds the findings the Hiring Manager has already been told about, by search.
+	KnownFindings map[string][]string
+}
+
+type ContinuousMonitoringState struct {
+	Email         string
+	Cycle         int
+	LastRun       time.Time
+	NextRun       time.Time
+	KnownFindings map[string][]string
+	NewFindings   map[string][]string
+	SearchErrors  map[string]SearchError
+	// Incomplete lists what each search that could not be fully carried out this cycle missed. Those searches
+	// are not compared with the known findings until a later cycle completes them.
+	Incomplete map[string][]string
+	Stopped    bool
+}
+
+type ContinuousMonitoringWorkflowResult = ContinuousMonitoringState
+
+// criminalSearchResult is the part of a criminal search result that monitoring is interested in.
+type criminalSearchResult struct {
+	Crimes       []string
+	Completeness string
+	Gaps         []SearchGap
+}
+
+// missedParts describes the parts of an incomplete search result that could not be carried out.
+func missedParts(r criminalSearchResult) []string {
+	if len(r.Gaps) == 0 {
+		return []string{"there were no records we could search"}
+	}
+
+	var missed []string
+	for _, gap := range r.Gaps {
+		part := gap.Name
+		if gap.Jurisdiction != "" {
+			part = fmt.Sprintf("%s in %s", gap.Name, gap.Jurisdiction)
+		}
+		missed = append(missed, fmt.Sprintf("%s (%s)", part, gap.Type))
+	}
+	return missed
+}
+
+// findingsFromResult extracts the findings from a search result that has been decoded without its concrete type,
+// such as the results held in BackgroundCheckState.SearchResults.
+func findingsFromResult(result interface{}) []string {
+	var findings []string
+
+	r, ok := result.(map[string]interface{})
+	if !ok {
+		return findings
+	}
+	crimes, _ := r["Crimes"].([]interface{})
+	for _, c := range crimes {
+		if s, ok := c.(string); ok {
+			findings = append(findings, s)
+		}
+	}
+
+	return findings
+}
+
+// ContinuousMonitoringInputFromCheck builds the input to enroll a candidate in monitoring from their completed
+// background check. Findings from the original report are treated as known so they are not reported again.
+func ContinuousMonitoringInputFromCheck(state *BackgroundCheckState, interval time.Duration) ContinuousMonitoringWorkflowInput {
+	input := ContinuousMonitoringWorkflowInput{
+		Email:            state.Email,
+		CandidateDetails: state.CandidateDetails,
+		Interval:         interval,
+		KnownFindings:    make(map[string][]string),
+	}
+	if state.SSNTrace != nil {
+		input.SSNTrace = *state.SSNTrace
+	}
+
+	for _, name := range monitoredSearches {
+		input.KnownFindings[name] = findingsFromResult(state.SearchResults[name])
+	}
+
+	return input
+}
+
+// newFindings returns the findings in current that are not in known.
+func newFindings(known []string, current []string) []string {
+	seen := make(map[string]bool, len(known))
+	for _, f := range known {
+		seen[f] = true
+	}
+
+	var result []string
+	for _, f := range current {
+		if !seen[f] {
+			result = append(result, f)
+			seen[f] = true
+		}
+	}
+
+	return result
+}
+
+// runMonitoringCycle re-runs each of the monitored searches and records any findings we haven't seen before.
+// A search that failed, or could only be partly carried out, is recorded as incomplete instead. Its findings are not
+// compared with the known findings, so the known findings only ever advance from a complete search.
+func runMonitoringCycle(ctx workflow.Context, input *ContinuousMonitoringWorkflowInput, state *ContinuousMonitoringState) {
+	logger := workflow.GetLogger(ctx)
+
+	searchState := BackgroundCheckState{
+		Email:            input.Email,
+		CandidateDetails: input.CandidateDetails,
+		SSNTrace:         &input.SSNTrace,
+	}
+
+	futures := make([]workflow.Future, len(monitoredSearches))
+	for i, name := range monitoredSearches {
+		search, err := LookupSearch(name)
+		if err != nil {
+			logger.Error("Unknown monitored search", "name", name)
+			continue
+		}
+
+		futures[i] = workflow.ExecuteChildWorkflow(
+			workflow.WithChildOptions(ctx, workflow.ChildWorkflowOptions{
+				WorkflowID: MonitoringSearchWorkflowID(input.Email, name, input.Cycle),
+			}),
+			search.Workflow,
+			search.Input(&searchState),
+		)
+	}
+
+	for i, name := range monitoredSearches {
+		if futures[i] == nil {
+			continue
+		}
+
+		var r criminalSearchResult
+		err := futures[i].Get(ctx, &r)
+		if err != nil {
+			logger.Error("Monitoring search failed", "name", name, "error", err)
+			state.SearchErrors[name] = NewSearchError(err)
+			state.Incomplete[name] = []string{err.Error()}
+			continue
+		}
+
+		if r.Completeness != SearchCompletenessComplete {
+			logger.Warn("Monitoring search incomplete", "name", name, "completeness", r.Completeness)
+			state.Incomplete[name] = missedParts(r)
+			continue
+		}
+
+		found := newFindings(state.KnownFindings[name], r.Crimes)
+		if len(found) > 0 {
+			state.NewFindings[name] = found
+			state.KnownFindings[name] = append(state.KnownFindings[name], found...)
+		}
+	}
+}
+
+// sendMonitoringAlertEmail lets the Hiring Manager know about new findings for a monitored employee, and about any
+// searches that could not be completed this cycle.
+func sendMonitoringAlertEmail(ctx workflow.Context, state *ContinuousMonitoringState) error {
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: time.Minute,
+	})
+	f := workflow.ExecuteActivity(ctx, a.SendMonitoringAlertEmail, activities.SendMonitoringAlertEmailInput{
+		Email:       state.Email,
+		NewFindings: state.NewFindings,
+		Incomplete:  state.Incomplete,
+	})
+	return f.Get(ctx, nil)
+}
+
+// waitForNextCycle waits until the next cycle is due. It returns false if monitoring was stopped while waiting.
+func waitForNextCycle(ctx workflow.Context, interval time.Duration) bool {
+	next := true
+
+	s := workflow.NewSelector(ctx)
+	s.AddReceive(workflow.GetSignalChannel(ctx, ContinuousMonitoringStopSignalName), func(c workflow.ReceiveChannel, more bool) {
+		c.Receive(ctx, nil)
+		next = false
+	})
+	s.AddFuture(workflow.NewTimer(ctx, interval), func(f workflow.Future) {})
+
+	s.Select(ctx)
+
+	return next
+}
+
+// @@@SNIPSTART background-checks-continuous-monitoring-workflow-definition
+
+// ContinuousMonitoring is a long running Workflow Definition that re-runs the criminal searches for an employee
+// on a schedule, and lets the Hiring Manager know about any findings that were not in previous results.
+// Each run of the workflow performs a single cycle and then continues as new, so the history stays bounded
+// however long the employee is monitored for.
+func ContinuousMonitoring(ctx workflow.Context, input *ContinuousMonitoringWorkflowInput) (*ContinuousMonitoringWorkflowResult, error) {
+	if input.Interval <= 0 {
+		input.Interval = DefaultMonitoringInterval
+	}
+	if input.KnownFindings == nil {
+		input.KnownFindings = make(map[string][]string)
+	}
+
+	state := ContinuousMonitoringState{
+		Email:         input.Email,
+		Cycle:         input.Cycle,
+		KnownFindings: input.KnownFindings,
+		NewFindings:   make(map[string][]string),
+		SearchErrors:  make(map[string]SearchError),
+		Incomplete:    make(map[string][]string),
+	}
+
+	err := workflow.SetQueryHandler(ctx, ContinuousMonitoringStatusQuery, func() (ContinuousMonitoringState, error) {
+		return state, nil
+	})
+	if err != nil {
+		return &state, err
+	}
+
+	state.LastRun = workflow.Now(ctx)
+	runMonitoringCycle(ctx, input, &state)
+
+	if len(state.NewFindings) > 0 || len(state.Incomplete) > 0 {
+		err = sendMonitoringAlertEmail(ctx, &state)
+		if err != nil {
+			return &state, err
+		}
+	}
+
+	state.NextRun = workflow.Now(ctx).Add(input.Interval)
+	if !waitForNextCycle(ctx, input.Interval) {
+		state.Stopped = true
+		return &state, nil
+	}
+
+	// Don't lose a stop request that arrived just as the next cycle became due.
+	if workflow.GetSignalChannel(ctx, ContinuousMonitoringStopSignalName).ReceiveAsync(nil) {
+		state.Stopped = true
+		return &state, nil
+	}
+
+	next := *input
+	next.Cycle++
+	next.KnownFindings = state.KnownFindings
+
+	return &state, workflow.NewContinueAsNewError(ctx, ContinuousMonitoring, &next)
+}
+
+// @@@SNIPEND
@@ -0,0 +1,133 @@
+package workflows_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/temporalio/background-checks/activities"
+	"github.com/temporalio/background-checks/workflows"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+)
+
+func TestContinuousMonitoringWorkflowNewFindings(t *testing.T) {
+	s := testsuite.WorkflowTestSuite{}
+	env := s.NewTestWorkflowEnvironment()
+	var a *activities.Activities
+
+	env.RegisterWorkflow(workflows.FederalCriminalSearch)
+	env.RegisterWorkflow(workflows.StateCriminalSearch)
+	env.OnWorkflow(workflows.FederalCriminalSearch, mock.Anything, mock.Anything).Return(
+		&workflows.FederalCriminalSearchWorkflowResult{
+			Crimes:       []string{"Counterfeiting", "Espionage"},
+			Completeness: workflows.SearchCompletenessComplete,
+		}, nil,
+	)
+	env.OnWorkflow(workflows.StateCriminalSearch, mock.Anything, mock.Anything).Return(
+		&workflows.StateCriminalSearchWorkflowResult{Completeness: workflows.SearchCompletenessComplete}, nil,
+	)
+
+	var alert activities.SendMonitoringAlertEmailInput
+	env.OnActivity(a.SendMonitoringAlertEmail, mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, input *activities.SendMonitoringAlertEmailInput) (*activities.SendMonitoringAlertEmailResult, error) {
+			alert = *input
+			return &activities.SendMonitoringAlertEmailResult{}, nil
+		},
+	)
+
+	env.ExecuteWorkflow(workflows.ContinuousMonitoring, &workflows.ContinuousMonitoringWorkflowInput{
+		Email:         "john@example.com",
+		KnownFindings: map[string][]string{"FederalCriminalSearch": {"Counterfeiting"}},
+	})
+
+	var continueAsNew *workflow.ContinueAsNewError
+	err := env.GetWorkflowError()
+	assert.True(t, errors.As(err, &continueAsNew))
+	assert.Equal(t, map[string][]string{"FederalCriminalSearch": {"Espionage"}}, alert.NewFindings)
+	assert.Empty(t, alert.Incomplete)
+}
+
+func TestContinuousMonitoringWorkflowIncomplete(t *testing.T) {
+	s := testsuite.WorkflowTestSuite{}
+	env := s.NewTestWorkflowEnvironment()
+	var a *activities.Activities
+
+	env.RegisterWorkflow(workflows.FederalCriminalSearch)
+	env.RegisterWorkflow(workflows.StateCriminalSearch)
+	env.OnWorkflow(workflows.FederalCriminalSearch, mock.Anything, mock.Anything).Return(
+		&workflows.FederalCriminalSearchWorkflowResult{
+			Crimes:       []string{"Espionage"},
+			Completeness: workflows.SearchCompletenessIncomplete,
+			Gaps: []workflows.SearchGap{
+				{Name: "John Smith", Jurisdiction: "1060 W. Addison St, Chicago, IL 60613", Type: activities.VendorErrorUnavailable},
+			},
+		}, nil,
+	)
+	env.OnWorkflow(workflows.StateCriminalSearch, mock.Anything, mock.Anything).Return(
+		&workflows.StateCriminalSearchWorkflowResult{Completeness: workflows.SearchCompletenessComplete}, nil,
+	)
+
+	var alert activities.SendMonitoringAlertEmailInput
+	env.OnActivity(a.SendMonitoringAlertEmail, mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, input *activities.SendMonitoringAlertEmailInput) (*activities.SendMonitoringAlertEmailResult, error) {
+			alert = *input
+			return &activities.SendMonitoringAlertEmailResult{}, nil
+		},
+	)
+
+	env.RegisterDelayedCallback(
+		func() {
+			env.SignalWorkflow(workflows.ContinuousMonitoringStopSignalName, nil)
+		},
+		workflows.DefaultMonitoringInterval/2,
+	)
+
+	env.ExecuteWorkflow(workflows.ContinuousMonitoring, &workflows.ContinuousMonitoringWorkflowInput{
+		Email:         "john@example.com",
+		KnownFindings: map[string][]string{"FederalCriminalSearch": {"Counterfeiting"}},
+	})
+
+	var result workflows.ContinuousMonitoringWorkflowResult
+	err := env.GetWorkflowResult(&result)
+	assert.NoError(t, err)
+	assert.True(t, result.Stopped)
+
+	// The Hiring Manager is told the search was incomplete, and the known findings don't advance from it.
+	assert.Empty(t, alert.NewFindings)
+	assert.Equal(t, map[string][]string{
+		"FederalCriminalSearch": {"John Smith in 1060 W. Addison St, Chicago, IL 60613 (VendorUnavailable)"},
+	}, alert.Incomplete)
+	assert.Equal(t, []string{"Counterfeiting"}, result.KnownFindings["FederalCriminalSearch"])
+}
+
+func TestContinuousMonitoringWorkflowStop(t *testing.T) {
+	s := testsuite.WorkflowTestSuite{}
+	env := s.NewTestWorkflowEnvironment()
+
+	env.RegisterWorkflow(workflows.FederalCriminalSearch)
+	env.RegisterWorkflow(workflows.StateCriminalSearch)
+	env.OnWorkflow(workflows.FederalCriminalSearch, mock.Anything, mock.Anything).Return(
+		&workflows.FederalCriminalSearchWorkflowResult{Completeness: workflows.SearchCompletenessComplete}, nil,
+	)
+	env.OnWorkflow(workflows.StateCriminalSearch, mock.Anything, mock.Anything).Return(
+		&workflows.StateCriminalSearchWorkflowResult{Completeness: workflows.SearchCompletenessComplete}, nil,
+	)
+
+	env.RegisterDelayedCallback(
+		func() {
+			env.SignalWorkflow(workflows.ContinuousMonitoringStopSignalName, nil)
+		},
+		workflows.DefaultMonitoringInterval/2,
+	)
+
+	env.ExecuteWorkflow(workflows.ContinuousMonitoring, &workflows.ContinuousMonitoringWorkflowInput{Email: "john@example.com"})
+
+	var result workflows.ContinuousMonitoringWorkflowResult
+	err := env.GetWorkflowResult(&result)
+	assert.NoError(t, err)
+	assert.True(t, result.Stopped)
+	assert.Empty(t, result.NewFindings)
+}
@@ -0,0 +1,208 @@
+package workflows
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/temporalio/background-checks/activities"
+	"go.temporal.io/sdk/workflow"
+)
+
+const (
+	// CountySearchLookback is how far back county criminal searches go.
+	CountySearchLookback = time.Hour * 24 * 365 * 7
+
+	CountySearchStatusComplete = "complete"
+	CountySearchStatusFailed   = "failed"
+)
+
+type CountyCriminalSearchesWorkflowInput struct {
+	FullName       string
+	Aliases        []string
+	KnownAddresses []KnownAddress
+}
+
+// CountyCriminalSearchResult is the outcome of searching one county's court records.
+type CountyCriminalSearchResult struct {
+	County string
+	State  string
+	// Addresses are the candidate's addresses in the county.
+	Addresses []KnownAddress
+	Status    string
+	Crimes    []string
+	// Findings are the crimes along with the name each was found under.
+	Findings []NameFinding
+	Error    string
+}
+
+type CountyCriminalSearchesWorkflowResult struct {
+	Counties []CountyCriminalSearchResult
+	// Completeness says whether every county was searched, see SearchCompletenessComplete. A candidate with no
+	// addresses within the lookback has no counties to search, so the search is reported as failed.
+	Completeness string
+	// Gaps are the counties that could not be searched.
+	Gaps []SearchGap
+}
+
+type CountyCriminalSearchWorkflowInput struct {
+	FullName string
+	Aliases  []string
+	County   string
+	State    string
+	From     time.Time
+	To       time.Time
+}
+
+type CountyCriminalSearchWorkflowResult struct {
+	Crimes   []string
+	Findings []NameFinding
+}
+
+// countiesFromAddresses groups the candidate's addresses by county, in the order the counties first appear.
+// Addresses the candidate moved out of before since are skipped, as they are outside the search's lookback.
+// Addresses in counties we can't identify are grouped by ZIP code, so they are reported as failures rather
+// than silently ignored.
+func countiesFromAddresses(ctx workflow.Context, addresses []KnownAddress, since time.Time) []CountyCriminalSearchResult {
+	logger := workflow.GetLogger(ctx)
+
+	var counties []CountyCriminalSearchResult
+	index := make(map[string]int)
+
+	for _, parsed := range addresses {
+		if !parsed.Current() && parsed.To.Before(since) {
+			logger.Info("Skipping address outside of lookback", "address", parsed.String())
+			continue
+		}
+
+		key := parsed.State + "/" + parsed.County
+		if parsed.County == "" {
+			key = parsed.State + "/" + parsed.ZipCode
+		}
+
+		i, ok := index[key]
+		if !ok {
+			i = len(counties)
+			index[key] = i
+			counties = append(counties, CountyCriminalSearchResult{County: parsed.County, State: parsed.State})
+			if parsed.County == "" {
+				counties[i].Status = CountySearchStatusFailed
+				counties[i].Error = fmt.Sprintf("unknown county for ZIP code %s", parsed.ZipCode)
+			}
+		}
+
+		counties[i].Addresses = append(counties[i].Addresses, parsed)
+	}
+
+	return counties
+}
+
+// @@@SNIPSTART background-checks-county-criminal-search-workflow-definition
+
+// CountyCriminalSearch is a Workflow Definition that searches a single county's court records for the candidate,
+// under each of the candidate's names.
+// This is executed as a Child Workflow by CountyCriminalSearches.
+func CountyCriminalSearch(ctx workflow.Context, input *CountyCriminalSearchWorkflowInput) (*CountyCriminalSearchWorkflowResult, error) {
+	var result CountyCriminalSearchWorkflowResult
+
+	ctx = withVendorOptions(ctx, VendorCountyCourts)
+
+	for _, name := range candidateNames(input.FullName, input.Aliases) {
+		var r activities.CountyCriminalRecordSearchResult
+		err := executeVendorActivity[activities.CountyCriminalRecordSearchResult](ctx, a.CountyCriminalRecordSearch, activities.CountyCriminalRecordSearchInput{
+			FullName: name,
+			County:   input.County,
+			State:    input.State,
+			From:     input.From.Format("2006-01-02"),
+			To:       input.To.Format("2006-01-02"),
+		}).Get(ctx, &r)
+		if err != nil {
+			return &result, err
+		}
+
+		result.Findings = addFindings(result.Findings, name, r.Crimes)
+	}
+
+	result.Crimes = findingValues(result.Findings)
+	return &result, nil
+}
+
+// @@@SNIPEND
+
+// @@@SNIPSTART background-checks-county-criminal-searches-workflow-definition
+
+// CountyCriminalSearches is a Workflow Definition that searches the court records of each county the candidate
+// has lived in, going back CountySearchLookback. The candidate's addresses are grouped by county, so that each
+// county is only searched once, by its own CountyCriminalSearch Child Workflow.
+// A failed county search is reported against that county rather than failing the whole search, and leaves the
+// search incomplete.
+// This is executed as a Child Workflow by the main Background Check.
+func CountyCriminalSearches(ctx workflow.Context, input *CountyCriminalSearchesWorkflowInput) (*CountyCriminalSearchesWorkflowResult, error) {
+	var result CountyCriminalSearchesWorkflowResult
+
+	logger := workflow.GetLogger(ctx)
+	parentID := workflow.GetInfo(ctx).WorkflowExecution.ID
+
+	to := workflow.Now(ctx)
+	from := to.Add(-CountySearchLookback)
+
+	result.Counties = countiesFromAddresses(ctx, input.KnownAddresses, from)
+	futures := make(map[int]workflow.ChildWorkflowFuture)
+
+	for i, county := range result.Counties {
+		if county.Status == CountySearchStatusFailed {
+			continue
+		}
+
+		futures[i] = workflow.ExecuteChildWorkflow(
+			workflow.WithChildOptions(ctx, workflow.ChildWorkflowOptions{
+				WorkflowID:          CountyCriminalSearchWorkflowID(parentID, county.State, county.County),
+				WaitForCancellation: true,
+			}),
+			CountyCriminalSearch,
+			CountyCriminalSearchWorkflowInput{
+				FullName: input.FullName,
+				Aliases:  input.Aliases,
+				County:   county.County,
+				State:    county.State,
+				From:     from,
+				To:       to,
+			},
+		)
+	}
+
+	for i := range result.Counties {
+		f, ok := futures[i]
+		if !ok {
+			continue
+		}
+
+		var r CountyCriminalSearchWorkflowResult
+		err := f.Get(ctx, &r)
+		if err != nil {
+			logger.Error("County criminal search failed", "county", result.Counties[i].County, "state", result.Counties[i].State, "error", err)
+			result.Counties[i].Status = CountySearchStatusFailed
+			result.Counties[i].Error = err.Error()
+			continue
+		}
+
+		result.Counties[i].Status = CountySearchStatusComplete
+		result.Counties[i].Crimes = r.Crimes
+		result.Counties[i].Findings = r.Findings
+	}
+
+	for _, county := range result.Counties {
+		if county.Status == CountySearchStatusFailed {
+			jurisdiction := county.State
+			if county.County != "" {
+				jurisdiction = county.County + ", " + county.State
+			}
+			result.Gaps = append(result.Gaps, newSearchGap(input.FullName, jurisdiction, errors.New(county.Error)))
+		}
+	}
+	result.Completeness = searchCompleteness(len(result.Counties), result.Gaps)
+
+	return &result, nil
+}
+
+// @@@SNIPEND
@@ -0,0 +1,139 @@
+package workflows_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/temporalio/background-checks/activities"
+	"github.com/temporalio/background-checks/workflows"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+)
+
+func TestParseAddress(t *testing.T) {
+	address, err := workflows.ParseAddress("1 E. 161 St, Bronx, NY 10451")
+	assert.NoError(t, err)
+	assert.Equal(t, workflows.KnownAddress{Address: "1 E. 161 St", City: "Bronx", State: "NY", ZipCode: "10451", County: "Bronx"}, address)
+
+	_, err = workflows.ParseAddress("")
+	assert.Error(t, err)
+	_, err = workflows.ParseAddress("1 Main St, Springfield, Illinois")
+	assert.Error(t, err)
+}
+
+func TestNormalizeAddressHistory(t *testing.T) {
+	addresses, problems := workflows.NormalizeAddressHistory([]activities.SSNTraceAddress{
+		{Address: "1060 W. Addison St, Chicago, IL 60613", From: "2015-03-01", To: "2018-06-30"},
+		{Address: "1 E. 161 St, Bronx, NY 10451", From: "2018-07-01"},
+		{Address: ""},
+		{Address: "1060 West  Addison Street, Chicago, IL 60613", From: "2012-01-01", To: "2015-02-28"},
+		{Address: "4 Jersey St, Boston, MA 02215", From: "2010-01-01", To: "2009-01-01"},
+		{Address: "1 Main St, Springfield, Illinois"},
+	})
+
+	assert.Len(t, problems, 3)
+
+	date := func(s string) time.Time {
+		d, err := time.Parse("2006-01-02", s)
+		assert.NoError(t, err)
+		return d
+	}
+
+	assert.Equal(t, []workflows.KnownAddress{
+		{Address: "1 E. 161 St", City: "Bronx", State: "NY", ZipCode: "10451", County: "Bronx", From: date("2018-07-01")},
+		{Address: "1060 W. Addison St", City: "Chicago", State: "IL", ZipCode: "60613", County: "Cook", From: date("2012-01-01"), To: date("2018-06-30")},
+	}, addresses)
+}
+
+func TestCountyCriminalSearchesWorkflow(t *testing.T) {
+	s := testsuite.WorkflowTestSuite{}
+	env := s.NewTestWorkflowEnvironment()
+	var a *activities.Activities
+
+	env.RegisterWorkflow(workflows.CountyCriminalSearch)
+
+	var searches []activities.CountyCriminalRecordSearchInput
+	env.OnActivity(a.CountyCriminalRecordSearch, mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, input *activities.CountyCriminalRecordSearchInput) (*activities.CountyCriminalRecordSearchResult, error) {
+			searches = append(searches, *input)
+			switch input.County {
+			case "Cook":
+				return nil, temporal.NewNonRetryableApplicationError("courthouse unavailable", "CourthouseUnavailable", errors.New("unavailable"))
+			case "Bronx":
+				return &activities.CountyCriminalRecordSearchResult{Crimes: []string{"Burglary, 2019"}}, nil
+			default:
+				return &activities.CountyCriminalRecordSearchResult{}, nil
+			}
+		},
+	)
+
+	address := func(s string, movedOut time.Time) workflows.KnownAddress {
+		a, err := workflows.ParseAddress(s)
+		assert.NoError(t, err)
+		a.To = movedOut
+		return a
+	}
+
+	env.ExecuteWorkflow(workflows.CountyCriminalSearches, &workflows.CountyCriminalSearchesWorkflowInput{
+		FullName: "John Smith",
+		KnownAddresses: []workflows.KnownAddress{
+			address("1 E. 161 St, Bronx, NY 10451", time.Time{}),
+			address("1060 W. Addison St, Chicago, IL 60613", time.Now().AddDate(-2, 0, 0)),
+			address("2 E. 161 St, Bronx, NY 10451", time.Now().AddDate(-4, 0, 0)),
+			address("1 Main St, Nowhere, KS 67000", time.Now().AddDate(-5, 0, 0)),
+			// The candidate moved out of Queens before the lookback, so it isn't searched.
+			address("41 Seaver Way, Queens, NY 11368", time.Now().AddDate(-10, 0, 0)),
+		},
+	})
+
+	var result workflows.CountyCriminalSearchesWorkflowResult
+	err := env.GetWorkflowResult(&result)
+	assert.NoError(t, err)
+
+	// Each county is only searched once, however many addresses the candidate had there.
+	assert.Len(t, searches, 2)
+	for _, search := range searches {
+		from, _ := time.Parse("2006-01-02", search.From)
+		to, _ := time.Parse("2006-01-02", search.To)
+		assert.InDelta(t, workflows.CountySearchLookback.Hours(), to.Sub(from).Hours(), 24)
+	}
+
+	assert.Len(t, result.Counties, 3)
+
+	assert.Equal(t, "Bronx", result.Counties[0].County)
+	assert.Equal(t, workflows.CountySearchStatusComplete, result.Counties[0].Status)
+	assert.Equal(t, []string{"Burglary, 2019"}, result.Counties[0].Crimes)
+	assert.Len(t, result.Counties[0].Addresses, 2)
+
+	assert.Equal(t, "Cook", result.Counties[1].County)
+	assert.Equal(t, workflows.CountySearchStatusFailed, result.Counties[1].Status)
+	assert.NotEmpty(t, result.Counties[1].Error)
+
+	assert.Equal(t, "KS", result.Counties[2].State)
+	assert.Equal(t, workflows.CountySearchStatusFailed, result.Counties[2].Status)
+
+	assert.Equal(t, workflows.SearchCompletenessIncomplete, result.Completeness)
+	assert.Len(t, result.Gaps, 2)
+	assert.Equal(t, "Cook, IL", result.Gaps[0].Jurisdiction)
+}
+
+func TestCountyCriminalSearchesWorkflowWithoutAddresses(t *testing.T) {
+	s := testsuite.WorkflowTestSuite{}
+	env := s.NewTestWorkflowEnvironment()
+
+	env.ExecuteWorkflow(workflows.CountyCriminalSearches, &workflows.CountyCriminalSearchesWorkflowInput{
+		FullName: "John Smith",
+	})
+
+	var result workflows.CountyCriminalSearchesWorkflowResult
+	err := env.GetWorkflowResult(&result)
+	assert.NoError(t, err)
+
+	// Nothing was searched, so the search mustn't be reported as clear.
+	assert.Empty(t, result.Counties)
+	assert.Equal(t, workflows.SearchCompletenessFailed, result.Completeness)
+}
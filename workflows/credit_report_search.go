@@ -0,0 +1,61 @@
+package workflows
+
+import (
+	"github.com/temporalio/background-checks/activities"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+const (
+	// CreditConsentMissingError is returned by the CreditReportSearch if it is started without the candidate's consent.
+	CreditConsentMissingError = "CreditConsentMissing"
+	// CreditConsentMissingReason is recorded in the report when the check refuses to run the CreditReportSearch.
+	CreditConsentMissingReason = "The candidate did not consent to an employment credit report"
+)
+
+type CreditReportSearchWorkflowInput struct {
+	FullName string
+	SSN      string
+	DOB      string
+	// Consent records that the candidate gave their separate consent to a credit report when accepting the check.
+	Consent bool
+}
+
+type CreditReportSearchWorkflowResult struct {
+	TradeLines   []activities.TradeLine
+	Collections  []activities.Collection
+	Bankruptcies []activities.Bankruptcy
+}
+
+// @@@SNIPSTART background-checks-credit-report-search-workflow-definition
+
+// CreditReportSearch is a Workflow Definition that pulls an employment credit report from the credit bureau.
+// A credit report may only be pulled with the candidate's separate consent, so the search refuses to run without it.
+// This is executed as a Child Workflow by the main Background Check.
+func CreditReportSearch(ctx workflow.Context, input *CreditReportSearchWorkflowInput) (*CreditReportSearchWorkflowResult, error) {
+	var result CreditReportSearchWorkflowResult
+
+	if !input.Consent {
+		return &result, temporal.NewApplicationError(CreditConsentMissingReason, CreditConsentMissingError)
+	}
+
+	ctx = withVendorOptions(ctx, VendorCreditBureau)
+
+	var report activities.CreditReportLookupResult
+	err := executeVendorActivity[activities.CreditReportLookupResult](
+		ctx,
+		a.CreditReportLookup,
+		activities.CreditReportLookupInput{FullName: input.FullName, SSN: input.SSN, DOB: input.DOB},
+	).Get(ctx, &report)
+	if err != nil {
+		return &result, err
+	}
+
+	result.TradeLines = report.TradeLines
+	result.Collections = report.Collections
+	result.Bankruptcies = report.Bankruptcies
+
+	return &result, nil
+}
+
+// @@@SNIPEND
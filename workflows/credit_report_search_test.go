@@ -0,0 +1,61 @@
+package workflows_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/temporalio/background-checks/activities"
+	"github.com/temporalio/background-checks/workflows"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+)
+
+func TestCreditReportSearchWorkflow(t *testing.T) {
+	s := testsuite.WorkflowTestSuite{}
+	env := s.NewTestWorkflowEnvironment()
+	var a *activities.Activities
+
+	report := activities.CreditReportLookupResult{
+		TradeLines: []activities.TradeLine{
+			{Creditor: "Acme Card Services", Type: "revolving", Opened: "2015-06", Balance: 4900, Limit: 5000, Status: "60 days late"},
+		},
+		Collections: []activities.Collection{
+			{Agency: "Recovery Partners", OriginalCreditor: "QuickLoan", Amount: 2750, Reported: "2021-08"},
+		},
+		Bankruptcies: []activities.Bankruptcy{
+			{Chapter: 7, Court: "US Bankruptcy Court, N.D. Ill.", Filed: "2016-11-03", Status: "discharged"},
+		},
+	}
+
+	env.OnActivity(a.CreditReportLookup, mock.Anything, &activities.CreditReportLookupInput{FullName: "John Smith", SSN: "222-22-2222", DOB: "1981-07-22"}).Return(&report, nil)
+
+	env.ExecuteWorkflow(workflows.CreditReportSearch, &workflows.CreditReportSearchWorkflowInput{FullName: "John Smith", SSN: "222-22-2222", DOB: "1981-07-22", Consent: true})
+
+	var result workflows.CreditReportSearchWorkflowResult
+	err := env.GetWorkflowResult(&result)
+	assert.NoError(t, err)
+
+	assert.Equal(t, workflows.CreditReportSearchWorkflowResult{
+		TradeLines:   report.TradeLines,
+		Collections:  report.Collections,
+		Bankruptcies: report.Bankruptcies,
+	}, result)
+}
+
+func TestCreditReportSearchWorkflowWithoutConsent(t *testing.T) {
+	s := testsuite.WorkflowTestSuite{}
+	env := s.NewTestWorkflowEnvironment()
+	var a *activities.Activities
+
+	env.RegisterActivity(a.CreditReportLookup)
+
+	env.ExecuteWorkflow(workflows.CreditReportSearch, &workflows.CreditReportSearchWorkflowInput{FullName: "John Smith", SSN: "222-22-2222"})
+
+	err := env.GetWorkflowError()
+	assert.Error(t, err)
+
+	var applicationErr *temporal.ApplicationError
+	assert.ErrorAs(t, err, &applicationErr)
+	assert.Equal(t, workflows.CreditConsentMissingError, applicationErr.Type())
+}
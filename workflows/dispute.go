@@ -0,0 +1,367 @@
+package workflows
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/temporalio/background-checks/activities"
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/workflow"
+)
+
+const (
+	DisputeSubmissionSignalName = "dispute-submission"
+	// ReinvestigationPeriod is the statutory deadline for resolving a dispute.
+	ReinvestigationPeriod = time.Hour * 24 * 30
+
+	DisputeOutcomeConfirmed  = "confirmed"
+	DisputeOutcomeAmended    = "amended"
+	DisputeOutcomeUnverified = "unverified"
+
+	// A dispute's status is pending until it has an outcome, or rejected or failed if it can't be reinvestigated.
+	DisputeStatusPending  = "pending"
+	DisputeStatusRejected = "rejected"
+	DisputeStatusFailed   = "failed"
+)
+
+type DisputeWorkflowInput struct {
+	ID      int
+	Dispute DisputeSubmissionSignal
+	// State is the state of the background check when the dispute was raised, so that the search is re-run with
+	// the same inputs, such as the candidate's consent to a credit report.
+	State          BackgroundCheckState
+	PreviousResult interface{}
+}
+
+type DisputeWorkflowResult struct {
+	Outcome string
+	Result  interface{}
+}
+
+// reinvestigate re-runs the disputed search. For searches that are performed by a researcher, such as
+// EmploymentVerification, this sends the request to a researcher to manually re-verify.
+func reinvestigate(ctx workflow.Context, search SearchDefinition, input *DisputeWorkflowInput) workflow.ChildWorkflowFuture {
+	ctx = workflow.WithChildOptions(ctx, workflow.ChildWorkflowOptions{
+		WorkflowID:          ReinvestigationWorkflowID(input.State.Email, search.Name, input.ID),
+		WaitForCancellation: true,
+		// Cancel rather than terminate the search if the dispute is closed, so that any researcher is released.
+		ParentClosePolicy: enums.PARENT_CLOSE_POLICY_REQUEST_CANCEL,
+	})
+
+	return workflow.ExecuteChildWorkflow(ctx, search.Workflow, search.Input(&input.State))
+}
+
+// reverify asks a researcher to check a disputed finding by hand, for searches that can't be re-run.
+func reverify(ctx workflow.Context, search SearchDefinition, input *DisputeWorkflowInput) workflow.ChildWorkflowFuture {
+	return startResearcherReview(ctx, fmt.Sprintf("reinvestigation-%d", input.ID), ResearcherReviewWorkflowInput{
+		Skill:    search.ReinvestigationSkill,
+		Subject:  fmt.Sprintf("Disputed %s finding for %s", search.Name, input.State.CandidateDetails.FullName),
+		Question: "Is the disputed finding accurate?",
+		Details: map[string]string{
+			"Candidate Name": input.State.CandidateDetails.FullName,
+			"Search":         search.Name,
+			"Finding":        input.Dispute.Finding,
+			"Reason":         input.Dispute.Reason,
+		},
+	})
+}
+
+// removeFinding returns a copy of a search result with any occurrences of the finding removed,
+// including any NameFinding that records the name it was found under.
+// Findings that cannot be verified within the reinvestigation period must be deleted from the report.
+func removeFinding(result interface{}, finding string) interface{} {
+	switch r := result.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(r))
+		for k, v := range r {
+			m[k] = removeFinding(v, finding)
+		}
+		return m
+	case []interface{}:
+		var l []interface{}
+		for _, v := range r {
+			if s, ok := v.(string); ok && s == finding {
+				continue
+			}
+			if m, ok := v.(map[string]interface{}); ok && m["Finding"] == finding {
+				continue
+			}
+			l = append(l, removeFinding(v, finding))
+		}
+		return l
+	default:
+		return result
+	}
+}
+
+// containsFinding reports whether a search result includes the finding, looking for it in the same places as
+// removeFinding.
+func containsFinding(result interface{}, finding string) bool {
+	switch r := result.(type) {
+	case map[string]interface{}:
+		for _, v := range r {
+			if containsFinding(v, finding) {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, v := range r {
+			if s, ok := v.(string); ok && s == finding {
+				return true
+			}
+			if m, ok := v.(map[string]interface{}); ok && m["Finding"] == finding {
+				return true
+			}
+			if containsFinding(v, finding) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ValidateDispute returns an error if the disputed finding isn't part of the given search's result in the report.
+// The dispute signal doesn't report back, so the API uses this to reject disputes the workflow would reject.
+func ValidateDispute(state *BackgroundCheckState, dispute DisputeSubmissionSignal) error {
+	result, ok := state.SearchResults[dispute.SearchName]
+	if !ok {
+		return fmt.Errorf("the report has no results for %s", dispute.SearchName)
+	}
+	if !containsFinding(result, dispute.Finding) {
+		return fmt.Errorf("%q is not a finding of %s", dispute.Finding, dispute.SearchName)
+	}
+	return nil
+}
+
+// @@@SNIPSTART background-checks-dispute-workflow-definition
+
+// Dispute is a Workflow Definition that reinvestigates a finding the candidate has disputed.
+// The disputed search is re-run, or manually re-verified by a researcher, and the new result is returned
+// so the background check can publish an amended report.
+// If the reinvestigation does not finish within the statutory period the finding is treated as unverified and removed.
+// This is executed as a Child Workflow by the main Background Check.
+func Dispute(ctx workflow.Context, input *DisputeWorkflowInput) (*DisputeWorkflowResult, error) {
+	var result DisputeWorkflowResult
+	logger := workflow.GetLogger(ctx)
+
+	// The search was already validated by the background check before the dispute was started.
+	search, _ := LookupSearch(input.Dispute.SearchName)
+
+	ctx, cancel := workflow.WithCancel(ctx)
+	defer cancel()
+
+	unverified := func() {
+		result.Outcome = DisputeOutcomeUnverified
+		result.Result = removeFinding(input.PreviousResult, input.Dispute.Finding)
+	}
+
+	s := workflow.NewSelector(ctx)
+
+	if search.ReinvestigationSkill != "" {
+		s.AddFuture(reverify(ctx, search, input), func(f workflow.Future) {
+			var r ResearcherReviewWorkflowResult
+
+			err := f.Get(ctx, &r)
+			if err != nil {
+				logger.Error("Reinvestigation failed", "search", search.Name, "error", err)
+				unverified()
+				return
+			}
+
+			if r.Confirmed {
+				result.Outcome = DisputeOutcomeConfirmed
+				result.Result = input.PreviousResult
+			} else {
+				result.Outcome = DisputeOutcomeAmended
+				result.Result = removeFinding(input.PreviousResult, input.Dispute.Finding)
+			}
+		})
+	} else {
+		s.AddFuture(reinvestigate(ctx, search, input), func(f workflow.Future) {
+			var r interface{}
+
+			err := f.Get(ctx, &r)
+			if err != nil {
+				logger.Error("Reinvestigation failed", "search", search.Name, "error", err)
+				unverified()
+				return
+			}
+
+			// Only the disputed finding matters, other parts of the result may have changed since the report.
+			result.Result = r
+			if containsFinding(r, input.Dispute.Finding) {
+				result.Outcome = DisputeOutcomeConfirmed
+			} else {
+				result.Outcome = DisputeOutcomeAmended
+			}
+		})
+	}
+	s.AddFuture(workflow.NewTimer(ctx, ReinvestigationPeriod), func(f workflow.Future) {
+		unverified()
+	})
+
+	s.Select(ctx)
+
+	// The reinvestigation doesn't count as unverified if the background check was cancelled.
+	if ctx.Err() != nil {
+		return &result, ctx.Err()
+	}
+
+	return &result, nil
+}
+
+// @@@SNIPEND
+
+// DisputeRecord tracks a dispute raised by the candidate against a finding in their report.
+type DisputeRecord struct {
+	ID         int
+	SearchName string
+	Finding    string
+	Reason     string
+	Status     string
+	Deadline   time.Time
+}
+
+// ReportAmendment records a change made to the report as the result of a dispute, or of a search that completed
+// after the report was released. DisputeID and Finding are only set for disputes.
+type ReportAmendment struct {
+	Version    int
+	DisputeID  int
+	SearchName string
+	Finding    string
+	Outcome    string
+	Previous   interface{}
+	Current    interface{}
+	AmendedAt  time.Time
+}
+
+// sendDisputeResultEmail lets the candidate know the outcome of their dispute.
+func (w *backgroundCheckWorkflow) sendDisputeResultEmail(ctx workflow.Context, dispute DisputeRecord) error {
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: time.Minute,
+	})
+	f := workflow.ExecuteActivity(ctx, a.SendDisputeResultEmail, activities.SendDisputeResultEmailInput{
+		Email:      w.Email,
+		Token:      TokenForWorkflow(ctx),
+		SearchName: dispute.SearchName,
+		Finding:    dispute.Finding,
+		Outcome:    dispute.Status,
+	})
+	return f.Get(ctx, nil)
+}
+
+// startDispute starts a Dispute child workflow for a finding the candidate has disputed.
+func (w *backgroundCheckWorkflow) startDispute(ctx workflow.Context, submission DisputeSubmissionSignal) {
+	dispute := DisputeRecord{
+		ID:         len(w.Disputes) + 1,
+		SearchName: submission.SearchName,
+		Finding:    submission.Finding,
+		Reason:     submission.Reason,
+		Status:     DisputeStatusPending,
+		Deadline:   workflow.Now(ctx).Add(ReinvestigationPeriod),
+	}
+
+	err := ValidateDispute(&w.BackgroundCheckState, submission)
+	if err != nil {
+		w.logger.Warn("Rejecting dispute", "search", submission.SearchName, "error", err)
+		dispute.Status = DisputeStatusRejected
+		w.Disputes = append(w.Disputes, dispute)
+		return
+	}
+	previous := w.SearchResults[submission.SearchName]
+
+	w.Disputes = append(w.Disputes, dispute)
+	w.pendingDisputes++
+
+	f := workflow.ExecuteChildWorkflow(
+		workflow.WithChildOptions(ctx, workflow.ChildWorkflowOptions{
+			WorkflowID:          DisputeWorkflowID(w.Email, dispute.ID),
+			WaitForCancellation: true,
+		}),
+		Dispute,
+		DisputeWorkflowInput{
+			ID:             dispute.ID,
+			Dispute:        submission,
+			State:          w.BackgroundCheckState,
+			PreviousResult: previous,
+		},
+	)
+
+	workflow.Go(ctx, func(ctx workflow.Context) {
+		defer func() { w.pendingDisputes-- }()
+
+		i := dispute.ID - 1
+
+		var r DisputeWorkflowResult
+		err := f.Get(ctx, &r)
+		if err != nil {
+			w.logger.Error("Dispute failed", "id", dispute.ID, "error", err)
+			w.Disputes[i].Status = DisputeStatusFailed
+			return
+		}
+
+		w.Disputes[i].Status = r.Outcome
+
+		if r.Outcome != DisputeOutcomeConfirmed {
+			w.amendReport(ctx, w.Disputes[i], previous, r.Result)
+		}
+
+		err = w.sendDisputeResultEmail(ctx, w.Disputes[i])
+		if err != nil {
+			w.logger.Error("Failed to send dispute result", "id", dispute.ID, "error", err)
+		}
+	})
+}
+
+// amendReport publishes a new version of the report with the result of a reinvestigation,
+// and lets the Hiring Manager know that the report has changed.
+func (w *backgroundCheckWorkflow) amendReport(ctx workflow.Context, dispute DisputeRecord, previous interface{}, current interface{}) {
+	w.ReportVersion++
+	w.SearchResults[dispute.SearchName] = current
+	w.recordTypedResult(ctx, dispute.SearchName, resultFuture(ctx, current))
+	w.ReportHistory = append(w.ReportHistory, ReportAmendment{
+		Version:    w.ReportVersion,
+		DisputeID:  dispute.ID,
+		SearchName: dispute.SearchName,
+		Finding:    dispute.Finding,
+		Outcome:    dispute.Status,
+		Previous:   previous,
+		Current:    current,
+		AmendedAt:  workflow.Now(ctx),
+	})
+
+	err := w.sendReportEmail(ctx, activities.HiringManagerEmail, true)
+	if err != nil {
+		w.logger.Error("Failed to send amended report", "id", dispute.ID, "error", err)
+	}
+}
+
+// resultFuture returns a ready future holding a search result, so that it can be decoded into the search's typed
+// result in the same way as the result of the search's own workflow.
+func resultFuture(ctx workflow.Context, result interface{}) workflow.Future {
+	future, settable := workflow.NewFuture(ctx)
+	payloads, err := converter.GetDefaultDataConverter().ToPayloads(result)
+	settable.Set(payloads, err)
+	return future
+}
+
+// handleDisputes starts a Dispute for each dispute the candidate submits once the report has been delivered.
+func (w *backgroundCheckWorkflow) handleDisputes(ctx workflow.Context) {
+	ch := workflow.GetSignalChannel(ctx, DisputeSubmissionSignalName)
+
+	workflow.Go(ctx, func(ctx workflow.Context) {
+		for {
+			var submission DisputeSubmissionSignal
+			ch.Receive(ctx, &submission)
+			w.startDispute(ctx, submission)
+		}
+	})
+}
+
+// waitForDisputes blocks until any outstanding disputes have been resolved.
+func (w *backgroundCheckWorkflow) waitForDisputes(ctx workflow.Context) error {
+	return workflow.Await(ctx, func() bool {
+		return w.pendingDisputes == 0
+	})
+}
@@ -0,0 +1,193 @@
+package workflows_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/temporalio/background-checks/workflows"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+)
+
+func TestDisputeWorkflowAmended(t *testing.T) {
+	s := testsuite.WorkflowTestSuite{}
+	env := s.NewTestWorkflowEnvironment()
+
+	env.RegisterWorkflow(workflows.FederalCriminalSearch)
+	env.OnWorkflow(workflows.FederalCriminalSearch, mock.Anything, mock.Anything).Return(
+		&workflows.FederalCriminalSearchWorkflowResult{Completeness: workflows.SearchCompletenessComplete}, nil,
+	)
+
+	input := workflows.DisputeWorkflowInput{
+		ID: 1,
+		Dispute: workflows.DisputeSubmissionSignal{
+			SearchName: "FederalCriminalSearch",
+			Finding:    "Counterfeiting",
+		},
+		State: workflows.BackgroundCheckState{
+			Email:            "john@example.com",
+			CandidateDetails: workflows.CandidateDetails{FullName: "John Smith"},
+			SSNTrace:         &workflows.SSNTraceWorkflowResult{},
+		},
+		PreviousResult: map[string]interface{}{"Crimes": []interface{}{"Counterfeiting"}},
+	}
+
+	env.ExecuteWorkflow(workflows.Dispute, &input)
+
+	var result workflows.DisputeWorkflowResult
+	err := env.GetWorkflowResult(&result)
+	assert.NoError(t, err)
+	assert.Equal(t, workflows.DisputeOutcomeAmended, result.Outcome)
+	assert.Equal(t, map[string]interface{}{
+		"Crimes":       nil,
+		"Findings":     nil,
+		"Completeness": workflows.SearchCompletenessComplete,
+		"Gaps":         nil,
+	}, result.Result)
+}
+
+func TestDisputeWorkflowUnverified(t *testing.T) {
+	s := testsuite.WorkflowTestSuite{}
+	env := s.NewTestWorkflowEnvironment()
+
+	env.RegisterWorkflow(workflows.FederalCriminalSearch)
+	env.OnWorkflow(workflows.FederalCriminalSearch, mock.Anything, mock.Anything).Return(&workflows.FederalCriminalSearchWorkflowResult{}, nil).After(workflows.ReinvestigationPeriod + time.Hour)
+
+	input := workflows.DisputeWorkflowInput{
+		ID: 1,
+		Dispute: workflows.DisputeSubmissionSignal{
+			SearchName: "FederalCriminalSearch",
+			Finding:    "Counterfeiting",
+		},
+		State: workflows.BackgroundCheckState{
+			Email:            "john@example.com",
+			CandidateDetails: workflows.CandidateDetails{FullName: "John Smith"},
+			SSNTrace:         &workflows.SSNTraceWorkflowResult{},
+		},
+		PreviousResult: map[string]interface{}{
+			"Crimes": []interface{}{"Counterfeiting", "Espionage"},
+			"Findings": []interface{}{
+				map[string]interface{}{"Name": "John Smith", "Finding": "Counterfeiting"},
+				map[string]interface{}{"Name": "Jack Smith", "Finding": "Espionage"},
+			},
+		},
+	}
+
+	env.ExecuteWorkflow(workflows.Dispute, &input)
+
+	var result workflows.DisputeWorkflowResult
+	err := env.GetWorkflowResult(&result)
+	assert.NoError(t, err)
+	assert.Equal(t, workflows.DisputeOutcomeUnverified, result.Outcome)
+	assert.Equal(t, map[string]interface{}{
+		"Crimes":   []interface{}{"Espionage"},
+		"Findings": []interface{}{map[string]interface{}{"Name": "Jack Smith", "Finding": "Espionage"}},
+	}, result.Result)
+}
+
+func TestDisputeWorkflowCreditReportKeepsConsent(t *testing.T) {
+	s := testsuite.WorkflowTestSuite{}
+	env := s.NewTestWorkflowEnvironment()
+
+	env.RegisterWorkflow(workflows.CreditReportSearch)
+	env.OnWorkflow(workflows.CreditReportSearch, mock.Anything, mock.Anything).Return(
+		func(ctx workflow.Context, input *workflows.CreditReportSearchWorkflowInput) (*workflows.CreditReportSearchWorkflowResult, error) {
+			// The search is re-run with the consent the candidate gave for the original check.
+			assert.True(t, input.Consent)
+			return &workflows.CreditReportSearchWorkflowResult{}, nil
+		},
+	)
+
+	input := workflows.DisputeWorkflowInput{
+		ID: 1,
+		Dispute: workflows.DisputeSubmissionSignal{
+			SearchName: "CreditReportSearch",
+			Finding:    "Bankruptcy",
+		},
+		State: workflows.BackgroundCheckState{
+			Email:            "john@example.com",
+			CandidateDetails: workflows.CandidateDetails{FullName: "John Smith", SSN: "111-11-1111"},
+			CreditConsent:    true,
+			SSNTrace:         &workflows.SSNTraceWorkflowResult{},
+		},
+		PreviousResult: map[string]interface{}{"Bankruptcies": []interface{}{"Bankruptcy"}},
+	}
+
+	env.ExecuteWorkflow(workflows.Dispute, &input)
+
+	var result workflows.DisputeWorkflowResult
+	err := env.GetWorkflowResult(&result)
+	assert.NoError(t, err)
+	assert.Equal(t, workflows.DisputeOutcomeAmended, result.Outcome)
+}
+
+func TestDisputeWorkflowReverifiedByResearcher(t *testing.T) {
+	s := testsuite.WorkflowTestSuite{}
+	env := s.NewTestWorkflowEnvironment()
+
+	// The reference check is not re-run, as that would contact the references again.
+	env.RegisterWorkflow(workflows.ReferenceCheck)
+	env.RegisterWorkflow(workflows.ResearcherReview)
+	env.OnWorkflow(workflows.ResearcherReview, mock.Anything, mock.Anything).Return(
+		func(ctx workflow.Context, input *workflows.ResearcherReviewWorkflowInput) (*workflows.ResearcherReviewWorkflowResult, error) {
+			assert.Equal(t, workflows.ResearcherSkillEmployment, input.Skill)
+			assert.Equal(t, "Would not rehire", input.Details["Finding"])
+			return &workflows.ResearcherReviewWorkflowResult{Confirmed: false, Researcher: "researcher1@example.com"}, nil
+		},
+	)
+
+	input := workflows.DisputeWorkflowInput{
+		ID: 1,
+		Dispute: workflows.DisputeSubmissionSignal{
+			SearchName: "ReferenceCheck",
+			Finding:    "Would not rehire",
+		},
+		State: workflows.BackgroundCheckState{
+			Email:            "john@example.com",
+			CandidateDetails: workflows.CandidateDetails{FullName: "John Smith"},
+		},
+		PreviousResult: map[string]interface{}{"Findings": []interface{}{"Would not rehire", "Late to meetings"}},
+	}
+
+	env.ExecuteWorkflow(workflows.Dispute, &input)
+
+	var result workflows.DisputeWorkflowResult
+	err := env.GetWorkflowResult(&result)
+	assert.NoError(t, err)
+	assert.Equal(t, workflows.DisputeOutcomeAmended, result.Outcome)
+	assert.Equal(t, map[string]interface{}{"Findings": []interface{}{"Late to meetings"}}, result.Result)
+}
+
+func TestDisputeWorkflowConfirmedWhenFindingRemains(t *testing.T) {
+	s := testsuite.WorkflowTestSuite{}
+	env := s.NewTestWorkflowEnvironment()
+
+	// The re-run finds a new crime as well, but the disputed one is still there.
+	env.RegisterWorkflow(workflows.FederalCriminalSearch)
+	env.OnWorkflow(workflows.FederalCriminalSearch, mock.Anything, mock.Anything).Return(
+		&workflows.FederalCriminalSearchWorkflowResult{Crimes: []string{"Counterfeiting", "Espionage"}}, nil,
+	)
+
+	input := workflows.DisputeWorkflowInput{
+		ID: 1,
+		Dispute: workflows.DisputeSubmissionSignal{
+			SearchName: "FederalCriminalSearch",
+			Finding:    "Counterfeiting",
+		},
+		State: workflows.BackgroundCheckState{
+			Email:            "john@example.com",
+			CandidateDetails: workflows.CandidateDetails{FullName: "John Smith"},
+			SSNTrace:         &workflows.SSNTraceWorkflowResult{},
+		},
+		PreviousResult: map[string]interface{}{"Crimes": []interface{}{"Counterfeiting"}},
+	}
+
+	env.ExecuteWorkflow(workflows.Dispute, &input)
+
+	var result workflows.DisputeWorkflowResult
+	err := env.GetWorkflowResult(&result)
+	assert.NoError(t, err)
+	assert.Equal(t, workflows.DisputeOutcomeConfirmed, result.Outcome)
+}
@@ -0,0 +1,87 @@
+package workflows
+
+import (
+	"time"
+
+	"github.com/temporalio/background-checks/activities"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+const (
+	// DrugScreenDeadline is how long we give the candidate to visit a collection site and the lab to return a result.
+	DrugScreenDeadline       = time.Hour * 24 * 14
+	DrugScreenTimedOutError  = "DrugScreenTimedOut"
+	DrugScreenResultNegative = "negative"
+	DrugScreenResultPositive = "positive"
+)
+
+type DrugScreenWorkflowInput struct {
+	FullName string
+	Email    string
+}
+
+type DrugScreenWorkflowResult struct {
+	OrderID    string
+	Result     string
+	Substances []string
+}
+
+// orderDrugScreen orders a drug test from the lab and sends the candidate the link to book their appointment.
+func orderDrugScreen(ctx workflow.Context, input *DrugScreenWorkflowInput) (string, error) {
+	ctx = withVendorOptions(ctx, VendorDrugScreenLab)
+
+	var order activities.OrderDrugScreenResult
+	err := executeVendorActivity[activities.OrderDrugScreenResult](ctx, a.OrderDrugScreen, activities.OrderDrugScreenInput{
+		FullName: input.FullName,
+		Email:    input.Email,
+	}).Get(ctx, &order)
+	if err != nil {
+		return "", err
+	}
+
+	err = workflow.ExecuteActivity(ctx, a.SendDrugScreenAppointmentEmail, activities.SendDrugScreenAppointmentEmailInput{
+		Email:          input.Email,
+		AppointmentURL: order.AppointmentURL,
+	}).Get(ctx, nil)
+
+	return order.OrderID, err
+}
+
+// @@@SNIPSTART background-checks-drug-screen-workflow-definition
+
+// DrugScreen is a Workflow Definition that orders a drug test from a lab and waits for the result.
+// The candidate has to book and attend an appointment at a collection site, so the result may take days to arrive.
+// Rather than polling the lab, the AwaitDrugScreenResult activity registers a callback with the lab and is completed
+// asynchronously by the API when the lab posts the result to our webhook.
+// This is executed as a Child Workflow by the main Background Check.
+func DrugScreen(ctx workflow.Context, input *DrugScreenWorkflowInput) (*DrugScreenWorkflowResult, error) {
+	var result DrugScreenWorkflowResult
+
+	orderID, err := orderDrugScreen(ctx, input)
+	if err != nil {
+		return &result, err
+	}
+	result.OrderID = orderID
+
+	// Registering the callback is retried if the lab is unavailable, but only until the deadline for the whole screen.
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		ScheduleToCloseTimeout: DrugScreenDeadline,
+	})
+
+	var labResult activities.DrugScreenLabResult
+	err = workflow.ExecuteActivity(ctx, a.AwaitDrugScreenResult, activities.AwaitDrugScreenResultInput{OrderID: orderID}).Get(ctx, &labResult)
+	if temporal.IsTimeoutError(err) {
+		return &result, temporal.NewApplicationError("no drug screen result was received before the deadline", DrugScreenTimedOutError)
+	}
+	if err != nil {
+		return &result, err
+	}
+
+	result.Result = labResult.Result
+	result.Substances = labResult.Substances
+
+	return &result, nil
+}
+
+// @@@SNIPEND
@@ -0,0 +1,68 @@
+package workflows_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/temporalio/background-checks/activities"
+	"github.com/temporalio/background-checks/workflows"
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+)
+
+func TestDrugScreenWorkflow(t *testing.T) {
+	s := testsuite.WorkflowTestSuite{}
+	env := s.NewTestWorkflowEnvironment()
+	var a *activities.Activities
+
+	env.OnActivity(a.OrderDrugScreen, mock.Anything, mock.Anything).Return(
+		&activities.OrderDrugScreenResult{OrderID: "1234", AppointmentURL: "http://lab.example.com/appointments/1234"}, nil,
+	)
+	env.OnActivity(a.SendDrugScreenAppointmentEmail, mock.Anything, &activities.SendDrugScreenAppointmentEmailInput{
+		Email:          "john@example.com",
+		AppointmentURL: "http://lab.example.com/appointments/1234",
+	}).Return(&activities.SendDrugScreenAppointmentEmailResult{}, nil).Once()
+	// The lab takes a few days to send the result back.
+	env.OnActivity(a.AwaitDrugScreenResult, mock.Anything, &activities.AwaitDrugScreenResultInput{OrderID: "1234"}).Return(
+		&activities.DrugScreenLabResult{OrderID: "1234", Result: workflows.DrugScreenResultPositive, Substances: []string{"THC"}}, nil,
+	).After(time.Hour * 24 * 3)
+
+	env.ExecuteWorkflow(workflows.DrugScreen, &workflows.DrugScreenWorkflowInput{FullName: "John Smith", Email: "john@example.com"})
+
+	var result workflows.DrugScreenWorkflowResult
+	err := env.GetWorkflowResult(&result)
+	assert.NoError(t, err)
+	assert.Equal(t, "1234", result.OrderID)
+	assert.Equal(t, workflows.DrugScreenResultPositive, result.Result)
+	assert.Equal(t, []string{"THC"}, result.Substances)
+	env.AssertExpectations(t)
+}
+
+func TestDrugScreenWorkflowTimesOut(t *testing.T) {
+	s := testsuite.WorkflowTestSuite{}
+	env := s.NewTestWorkflowEnvironment()
+	var a *activities.Activities
+
+	env.OnActivity(a.OrderDrugScreen, mock.Anything, mock.Anything).Return(
+		&activities.OrderDrugScreenResult{OrderID: "1234"}, nil,
+	)
+	env.OnActivity(a.SendDrugScreenAppointmentEmail, mock.Anything, mock.Anything).Return(
+		&activities.SendDrugScreenAppointmentEmailResult{}, nil,
+	)
+	// The candidate never attends their appointment, so the lab never calls back.
+	env.OnActivity(a.AwaitDrugScreenResult, mock.Anything, mock.Anything).Return(
+		nil, temporal.NewTimeoutError(enums.TIMEOUT_TYPE_START_TO_CLOSE, nil),
+	)
+
+	env.ExecuteWorkflow(workflows.DrugScreen, &workflows.DrugScreenWorkflowInput{FullName: "John Smith", Email: "john@example.com"})
+
+	err := env.GetWorkflowError()
+	assert.Error(t, err)
+
+	var applicationErr *temporal.ApplicationError
+	assert.ErrorAs(t, err, &applicationErr)
+	assert.Equal(t, workflows.DrugScreenTimedOutError, applicationErr.Type())
+}
@@ -0,0 +1,121 @@
+package workflows
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/temporalio/background-checks/activities"
+	"go.temporal.io/sdk/workflow"
+)
+
+const (
+	EducationVerificationSourceVendor     = "vendor"
+	EducationVerificationSourceResearcher = "researcher"
+)
+
+type EducationVerificationWorkflowInput struct {
+	FullName  string
+	Aliases   []string
+	Education []EducationRecord
+}
+
+// EducationVerificationRecordResult is the outcome of verifying one of the candidate's qualifications.
+type EducationVerificationRecordResult struct {
+	Institution    string
+	Degree         string
+	GraduationDate string
+	Verified       bool
+	// MatchedName is the candidate's name the vendor's record was found under, if it found one.
+	MatchedName string
+	// Source says whether the qualification was checked with the education vendor or by a researcher.
+	Source string
+	Notes  string
+}
+
+type EducationVerificationWorkflowResult struct {
+	Records []EducationVerificationRecordResult
+}
+
+// educationRecordMatches reports whether the vendor's record agrees with what the candidate told us.
+func educationRecordMatches(record EducationRecord, found activities.EducationRecordSearchResult) bool {
+	return strings.EqualFold(strings.TrimSpace(record.Degree), strings.TrimSpace(found.Degree)) &&
+		strings.TrimSpace(record.GraduationDate) == strings.TrimSpace(found.GraduationDate)
+}
+
+// @@@SNIPSTART background-checks-education-verification-workflow-definition
+
+// EducationVerification is a Workflow Definition that checks each of the candidate's qualifications with the
+// education vendor, trying each of the candidate's names in turn as they may have studied under a former name.
+// Qualifications the vendor has no record of are sent to a researcher to verify manually.
+// This is executed as a Child Workflow by the main Background Check.
+func EducationVerification(ctx workflow.Context, input *EducationVerificationWorkflowInput) (*EducationVerificationWorkflowResult, error) {
+	var result EducationVerificationWorkflowResult
+
+	ctx = withVendorOptions(ctx, VendorEducation)
+
+	result.Records = make([]EducationVerificationRecordResult, len(input.Education))
+	reviews := make(map[int]workflow.ChildWorkflowFuture)
+
+	for i, record := range input.Education {
+		result.Records[i] = EducationVerificationRecordResult{
+			Institution:    record.Institution,
+			Degree:         record.Degree,
+			GraduationDate: record.GraduationDate,
+			Source:         EducationVerificationSourceVendor,
+		}
+
+		var found activities.EducationRecordSearchResult
+		for _, name := range candidateNames(input.FullName, input.Aliases) {
+			err := executeVendorActivity[activities.EducationRecordSearchResult](ctx, a.EducationRecordSearch, activities.EducationRecordSearchInput{
+				FullName:    name,
+				Institution: record.Institution,
+			}).Get(ctx, &found)
+			if err != nil {
+				return &result, err
+			}
+			if found.RecordFound {
+				result.Records[i].MatchedName = name
+				break
+			}
+		}
+
+		if found.RecordFound {
+			result.Records[i].Verified = educationRecordMatches(record, found)
+			continue
+		}
+
+		// The vendor doesn't know about this qualification, so ask a researcher to check with the institution.
+		result.Records[i].Source = EducationVerificationSourceResearcher
+		reviews[i] = startResearcherReview(ctx, fmt.Sprintf("education-%d", i+1), ResearcherReviewWorkflowInput{
+			Skill:    ResearcherSkillEducation,
+			Subject:  "Education Verification",
+			Question: fmt.Sprintf("Did %s receive a %s from %s in %s?", input.FullName, record.Degree, record.Institution, record.GraduationDate),
+			Details: map[string]string{
+				"Candidate":       input.FullName,
+				"Institution":     record.Institution,
+				"Degree":          record.Degree,
+				"Graduation Date": record.GraduationDate,
+			},
+		})
+	}
+
+	for i := range result.Records {
+		review, ok := reviews[i]
+		if !ok {
+			continue
+		}
+
+		var r ResearcherReviewWorkflowResult
+		err := review.Get(ctx, &r)
+		if err != nil {
+			return &result, err
+		}
+
+		result.Records[i].Verified = r.Confirmed
+		result.Records[i].Notes = r.Notes
+	}
+
+	return &result, nil
+}
+
+// @@@SNIPEND
@@ -0,0 +1,57 @@
+package workflows_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/temporalio/background-checks/activities"
+	"github.com/temporalio/background-checks/workflows"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+)
+
+func TestEducationVerificationWorkflow(t *testing.T) {
+	s := testsuite.WorkflowTestSuite{}
+	env := s.NewTestWorkflowEnvironment()
+	var a *activities.Activities
+
+	env.RegisterWorkflow(workflows.ResearcherReview)
+
+	env.OnActivity(a.EducationRecordSearch, mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, input *activities.EducationRecordSearchInput) (*activities.EducationRecordSearchResult, error) {
+			if input.Institution == "State University" {
+				return &activities.EducationRecordSearchResult{RecordFound: true, Degree: "BSc Computer Science", GraduationDate: "2003-05"}, nil
+			}
+			return &activities.EducationRecordSearchResult{}, nil
+		},
+	)
+
+	var review workflows.ResearcherReviewWorkflowInput
+	env.OnWorkflow(workflows.ResearcherReview, mock.Anything, mock.Anything).Return(
+		func(ctx workflow.Context, input *workflows.ResearcherReviewWorkflowInput) (*workflows.ResearcherReviewWorkflowResult, error) {
+			review = *input
+			return &workflows.ResearcherReviewWorkflowResult{Confirmed: true, Notes: "Confirmed with registrar"}, nil
+		},
+	)
+
+	env.ExecuteWorkflow(workflows.EducationVerification, &workflows.EducationVerificationWorkflowInput{
+		FullName: "John Smith",
+		Education: []workflows.EducationRecord{
+			{Institution: "State University", Degree: "BSc Computer Science", GraduationDate: "2003-05"},
+			{Institution: "Small College", Degree: "MBA", GraduationDate: "2008-06"},
+		},
+	})
+
+	var result workflows.EducationVerificationWorkflowResult
+	err := env.GetWorkflowResult(&result)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []workflows.EducationVerificationRecordResult{
+		{Institution: "State University", Degree: "BSc Computer Science", GraduationDate: "2003-05", Verified: true, MatchedName: "John Smith", Source: workflows.EducationVerificationSourceVendor},
+		{Institution: "Small College", Degree: "MBA", GraduationDate: "2008-06", Verified: true, Source: workflows.EducationVerificationSourceResearcher, Notes: "Confirmed with registrar"},
+	}, result.Records)
+	assert.Equal(t, workflows.ResearcherSkillEducation, review.Skill)
+	assert.Equal(t, "Small College", review.Details["Institution"])
+}
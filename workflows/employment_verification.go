@@ -1,10 +1,11 @@
 package workflows
 
 import (
-	"math/rand"
+	"fmt"
 	"time"
 
 	"github.com/temporalio/background-checks/activities"
+	"go.temporal.io/sdk/temporal"
 	"go.temporal.io/sdk/workflow"
 )
 
@@ -12,70 +13,71 @@ const (
 	EmploymentVerificationDetailsQuery         = "employment-verification-details"
 	EmploymentVerificationSubmissionSignalName = "employment-verification-submission"
 	ResearchDeadline                           = time.Hour * 24 * 7
-)
-
-// chooseResearcher encapsulates the logic that randomly chooses a Researcher using a Side Effect.
-func chooseResearcher(ctx workflow.Context, input *EmploymentVerificationWorkflowInput) (string, error) {
-	researchers := []string{
-		"researcher1@example.com",
-		"researcher2@example.com",
-		"researcher3@example.com",
-	}
+	// DefaultResearchAssignmentSLA is how long a researcher has to complete their research before it is reassigned.
+	DefaultResearchAssignmentSLA = time.Hour * 24 * 3
+	// DefaultResearchReminderInterval is how often the assigned researcher is reminded about outstanding research.
+	DefaultResearchReminderInterval = time.Hour * 24
 
-	// Here we just pick a random researcher.
-	// In a real use case you may round-robin, decide based on price or current workload,
-	// or fetch a researcher from a third party API.
-	var researcher string
-	r := workflow.SideEffect(ctx, func(ctx workflow.Context) interface{} {
-		return researchers[rand.Intn(len(researchers))]
-	})
-	err := r.Get(&researcher)
-
-	return researcher, err
-}
+	EmploymentVerificationTimedOutError = "EmploymentVerificationTimedOut"
+)
 
 // emailEmploymentVerificationRequest encapsulates the logic that calls for the execution an Activity.
-func emailEmploymentVerificationRequest(ctx workflow.Context, input *EmploymentVerificationWorkflowInput, email string) error {
-	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
-		StartToCloseTimeout: time.Minute,
-	})
+// If reminder is true the email reminds the researcher about a request they have already been sent.
+func emailEmploymentVerificationRequest(ctx workflow.Context, assignment *researchAssignment, reminder bool) error {
+	ctx = withVendorOptions(ctx, VendorResearchers)
 
 	evsend := workflow.ExecuteActivity(ctx, a.SendEmploymentVerificationRequestEmail, activities.SendEmploymentVerificationEmailInput{
-		Email: email,
-		Token: TokenForWorkflow(ctx),
+		Email:    assignment.Researcher,
+		Token:    assignment.Token,
+		Reminder: reminder,
 	})
 	return evsend.Get(ctx, nil)
 }
 
 // waitForEmploymentVerificationSubmission encapsulates the logic that waits on and handles a Signal.
-func waitForEmploymentVerificationSubmission(ctx workflow.Context) (*EmploymentVerificationSubmission, error) {
-	var response EmploymentVerificationSubmission
-	var err error
-
-	s := workflow.NewSelector(ctx)
-
-	ch := workflow.GetSignalChannel(ctx, EmploymentVerificationSubmissionSignalName)
-	s.AddReceive(ch, func(c workflow.ReceiveChannel, more bool) {
-		var submission EmploymentVerificationSubmissionSignal
-		c.Receive(ctx, &submission)
-
-		response = EmploymentVerificationSubmission(submission)
-	})
-	s.AddFuture(workflow.NewTimer(ctx, ResearchDeadline), func(f workflow.Future) {
-		err = f.Get(ctx, nil)
-
-		// We should probably fail the (child) workflow here.
-		response.EmploymentVerificationComplete = false
-		response.EmployerVerified = false
-	})
-
-	s.Select(ctx)
-
-	return &response, err
+// The assigned researcher is reminded periodically until they respond or the assignment ends.
+// Submissions made with a revoked token are ignored. A nil response means the assignment ended without a submission.
+func waitForEmploymentVerificationSubmission(ctx workflow.Context, input *EmploymentVerificationWorkflowInput, assignment *researchAssignment, until time.Time) *EmploymentVerificationSubmission {
+	var response *EmploymentVerificationSubmission
+
+	logger := workflow.GetLogger(ctx)
+
+	waitForResearchSubmission(
+		ctx,
+		EmploymentVerificationSubmissionSignalName,
+		until,
+		input.ReminderInterval,
+		func(ctx workflow.Context) error {
+			return emailEmploymentVerificationRequest(ctx, assignment, true)
+		},
+		func(c workflow.ReceiveChannel) bool {
+			var submission EmploymentVerificationSubmissionSignal
+			c.Receive(ctx, &submission)
+
+			if submission.Token != assignment.Token {
+				logger.Warn("Ignoring submission for a revoked assignment")
+				return false
+			}
+
+			response = &EmploymentVerificationSubmission{
+				EmploymentVerificationComplete: submission.EmploymentVerificationComplete,
+				EmployerVerified:               submission.EmployerVerified,
+			}
+			return true
+		},
+	)
+
+	return response
 }
 
 type EmploymentVerificationWorkflowInput struct {
 	CandidateDetails CandidateDetails
+	// AssignmentSLA is how long each researcher has before the verification is reassigned.
+	// DefaultResearchAssignmentSLA is used if it is not set.
+	AssignmentSLA time.Duration
+	// ReminderInterval is how often the assigned researcher is reminded.
+	// DefaultResearchReminderInterval is used if it is not set.
+	ReminderInterval time.Duration
 }
 
 type EmploymentVerificationWorkflowResult struct {
@@ -85,32 +87,57 @@ type EmploymentVerificationWorkflowResult struct {
 
 // @@@SNIPSTART background-checks-employment-verification-workflow-definition
 
-// EmploymentVerification is a Workflow Definition that calls for the execution of a Side Effect, and an Activity,
-// but then waits on and handles a Signal. It is also capable of handling a Query to get Candidate Details.
+// EmploymentVerification is a Workflow Definition that requests a researcher from the ResearcherPool, calls for the
+// execution of an Activity, but then waits on and handles a Signal. It is also capable of handling a Query to get Candidate Details.
+// If the assigned researcher doesn't respond within the SLA the verification is reassigned to another researcher,
+// and the previous researcher's token is revoked. If nobody responds before the ResearchDeadline the workflow fails.
 // This is executed as a Child Workflow by the main Background Check.
 func EmploymentVerification(ctx workflow.Context, input *EmploymentVerificationWorkflowInput) (*EmploymentVerificationWorkflowResult, error) {
 	var result EmploymentVerificationWorkflowResult
+	var assignment researchAssignment
 
-	err := workflow.SetQueryHandler(ctx, EmploymentVerificationDetailsQuery, func() (CandidateDetails, error) {
+	if input.AssignmentSLA <= 0 {
+		input.AssignmentSLA = DefaultResearchAssignmentSLA
+	}
+	if input.ReminderInterval <= 0 {
+		input.ReminderInterval = DefaultResearchReminderInterval
+	}
+
+	err := workflow.SetQueryHandler(ctx, EmploymentVerificationDetailsQuery, func(token string) (CandidateDetails, error) {
+		if token != assignment.Token {
+			return CandidateDetails{}, fmt.Errorf("this verification has been reassigned or cancelled")
+		}
 		return input.CandidateDetails, nil
 	})
 	if err != nil {
 		return &result, err
 	}
 
-	researcher, err := chooseResearcher(ctx, input)
-	if err != nil {
-		return &result, err
-	}
+	deadline := workflow.Now(ctx).Add(ResearchDeadline)
+	request := ResearcherAssignmentRequest{Skill: ResearcherSkillEmployment}
+
+	done, err := assignResearch(ctx, request, input.AssignmentSLA, deadline, &assignment, func(until time.Time) (bool, error) {
+		err := emailEmploymentVerificationRequest(ctx, &assignment, false)
+		if err != nil {
+			return false, err
+		}
 
-	err = emailEmploymentVerificationRequest(ctx, input, researcher)
+		submission := waitForEmploymentVerificationSubmission(ctx, input, &assignment, until)
+		if submission == nil {
+			return false, nil
+		}
+
+		result = EmploymentVerificationWorkflowResult(*submission)
+		return true, nil
+	})
 	if err != nil {
 		return &result, err
 	}
-	submission, err := waitForEmploymentVerificationSubmission(ctx)
+	if !done {
+		return &result, temporal.NewApplicationError("employment verification timed out", EmploymentVerificationTimedOutError)
+	}
 
-	result = EmploymentVerificationWorkflowResult(*submission)
-	return &result, err
+	return &result, nil
 }
 
 // @@@SNIPEND
@@ -3,19 +3,47 @@ package workflows_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/temporalio/background-checks/activities"
 	"github.com/temporalio/background-checks/workflows"
+	"go.temporal.io/sdk/temporal"
 	"go.temporal.io/sdk/testsuite"
 )
 
+// mockResearcherPool stands in for the ResearcherPool workflow, assigning the first researcher that isn't excluded.
+func mockResearcherPool(env *testsuite.TestWorkflowEnvironment, researchers ...string) {
+	env.OnSignalExternalWorkflow(mock.Anything, workflows.ResearcherPoolWorkflowID(), "", workflows.ResearcherAssignmentRequestSignalName, mock.Anything).Return(
+		func(namespace, workflowID, runID, signalName string, arg interface{}) error {
+			request := arg.(workflows.ResearcherAssignmentRequest)
+			excluded := make(map[string]bool)
+			for _, r := range request.Exclude {
+				excluded[r] = true
+			}
+			for _, r := range researchers {
+				if !excluded[r] {
+					env.SignalWorkflow(
+						workflows.ResearcherAssignmentSignalName,
+						workflows.ResearcherAssignmentSignal{AssignmentID: request.AssignmentID, Researcher: r},
+					)
+					break
+				}
+			}
+			return nil
+		},
+	)
+	env.OnSignalExternalWorkflow(mock.Anything, workflows.ResearcherPoolWorkflowID(), "", workflows.ResearcherReleaseSignalName, mock.Anything).Return(nil)
+}
+
 func TestEmploymentVerificationWorkflow(t *testing.T) {
 	s := testsuite.WorkflowTestSuite{}
 	env := s.NewTestWorkflowEnvironment()
 	var a *activities.Activities
 
+	mockResearcherPool(env, "researcher1@example.com", "researcher2@example.com")
+
 	details := workflows.CandidateDetails{
 		FullName: "John Smith",
 		SSN:      "111-11-1111",
@@ -23,8 +51,10 @@ func TestEmploymentVerificationWorkflow(t *testing.T) {
 		Address:  "1 Chestnut Avenue",
 	}
 
+	var token string
 	env.OnActivity(a.SendEmploymentVerificationRequestEmail, mock.Anything, mock.Anything).Return(
 		func(ctx context.Context, input *activities.SendEmploymentVerificationEmailInput) (*activities.SendEmploymentVerificationEmailResult, error) {
+			token = input.Token
 			return &activities.SendEmploymentVerificationEmailResult{}, nil
 		},
 	)
@@ -33,10 +63,10 @@ func TestEmploymentVerificationWorkflow(t *testing.T) {
 		func() {
 			env.SignalWorkflow(
 				workflows.EmploymentVerificationSubmissionSignalName,
-				workflows.EmploymentVerificationSubmissionSignal{EmploymentVerificationComplete: true, EmployerVerified: true},
+				workflows.EmploymentVerificationSubmissionSignal{EmploymentVerificationComplete: true, EmployerVerified: true, Token: token},
 			)
 		},
-		0,
+		time.Minute,
 	)
 
 	env.ExecuteWorkflow(workflows.EmploymentVerification, &workflows.EmploymentVerificationWorkflowInput{CandidateDetails: details})
@@ -48,11 +78,13 @@ func TestEmploymentVerificationWorkflow(t *testing.T) {
 	assert.Equal(t, workflows.EmploymentVerificationWorkflowResult{EmploymentVerificationComplete: true, EmployerVerified: true}, result)
 }
 
-func TestEmploymentVerificationWorkflowTimeout(t *testing.T) {
+func TestEmploymentVerificationWorkflowReassignment(t *testing.T) {
 	s := testsuite.WorkflowTestSuite{}
 	env := s.NewTestWorkflowEnvironment()
 	var a *activities.Activities
 
+	mockResearcherPool(env, "researcher1@example.com", "researcher2@example.com")
+
 	details := workflows.CandidateDetails{
 		FullName: "John Smith",
 		SSN:      "111-11-1111",
@@ -60,17 +92,118 @@ func TestEmploymentVerificationWorkflowTimeout(t *testing.T) {
 		Address:  "1 Chestnut Avenue",
 	}
 
+	var requests []activities.SendEmploymentVerificationEmailInput
+	reminders := 0
 	env.OnActivity(a.SendEmploymentVerificationRequestEmail, mock.Anything, mock.Anything).Return(
 		func(ctx context.Context, input *activities.SendEmploymentVerificationEmailInput) (*activities.SendEmploymentVerificationEmailResult, error) {
+			if input.Reminder {
+				reminders++
+			} else {
+				requests = append(requests, *input)
+			}
 			return &activities.SendEmploymentVerificationEmailResult{}, nil
 		},
 	)
 
-	env.ExecuteWorkflow(workflows.EmploymentVerification, &workflows.EmploymentVerificationWorkflowInput{CandidateDetails: details})
+	// The first researcher responds after the verification has been reassigned, so their submission is ignored.
+	env.RegisterDelayedCallback(
+		func() {
+			env.SignalWorkflow(
+				workflows.EmploymentVerificationSubmissionSignalName,
+				workflows.EmploymentVerificationSubmissionSignal{EmploymentVerificationComplete: true, EmployerVerified: false, Token: requests[0].Token},
+			)
+		},
+		time.Hour*25,
+	)
+	env.RegisterDelayedCallback(
+		func() {
+			env.SignalWorkflow(
+				workflows.EmploymentVerificationSubmissionSignalName,
+				workflows.EmploymentVerificationSubmissionSignal{EmploymentVerificationComplete: true, EmployerVerified: true, Token: requests[1].Token},
+			)
+		},
+		time.Hour*26,
+	)
+
+	env.ExecuteWorkflow(workflows.EmploymentVerification, &workflows.EmploymentVerificationWorkflowInput{
+		CandidateDetails: details,
+		AssignmentSLA:    time.Hour * 24,
+		ReminderInterval: time.Hour * 8,
+	})
 
 	var result workflows.EmploymentVerificationWorkflowResult
 	err := env.GetWorkflowResult(&result)
 	assert.NoError(t, err)
 
-	assert.Equal(t, workflows.EmploymentVerificationWorkflowResult{EmploymentVerificationComplete: false, EmployerVerified: false}, result)
+	assert.Equal(t, workflows.EmploymentVerificationWorkflowResult{EmploymentVerificationComplete: true, EmployerVerified: true}, result)
+	assert.Len(t, requests, 2)
+	assert.NotEqual(t, requests[0].Email, requests[1].Email)
+	assert.NotEqual(t, requests[0].Token, requests[1].Token)
+	assert.Equal(t, 2, reminders)
+}
+
+func TestEmploymentVerificationWorkflowCancelled(t *testing.T) {
+	s := testsuite.WorkflowTestSuite{}
+	env := s.NewTestWorkflowEnvironment()
+	var a *activities.Activities
+
+	mockResearcherPool(env, "researcher1@example.com")
+
+	details := workflows.CandidateDetails{
+		FullName: "John Smith",
+		SSN:      "111-11-1111",
+		DOB:      "1981-01-01",
+		Address:  "1 Chestnut Avenue",
+	}
+
+	var requests []activities.SendEmploymentVerificationEmailInput
+	env.OnActivity(a.SendEmploymentVerificationRequestEmail, mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, input *activities.SendEmploymentVerificationEmailInput) (*activities.SendEmploymentVerificationEmailResult, error) {
+			requests = append(requests, *input)
+			return &activities.SendEmploymentVerificationEmailResult{}, nil
+		},
+	)
+
+	env.RegisterDelayedCallback(env.CancelWorkflow, time.Hour*2)
+
+	env.ExecuteWorkflow(workflows.EmploymentVerification, &workflows.EmploymentVerificationWorkflowInput{
+		CandidateDetails: details,
+	})
+
+	assert.True(t, temporal.IsCanceledError(env.GetWorkflowError()))
+	if assert.Len(t, requests, 1) {
+		// The researcher's token is revoked once the verification is cancelled.
+		_, err := env.QueryWorkflow(workflows.EmploymentVerificationDetailsQuery, requests[0].Token)
+		assert.Error(t, err)
+	}
+}
+
+func TestEmploymentVerificationWorkflowTimeout(t *testing.T) {
+	s := testsuite.WorkflowTestSuite{}
+	env := s.NewTestWorkflowEnvironment()
+	var a *activities.Activities
+
+	mockResearcherPool(env, "researcher1@example.com", "researcher2@example.com")
+
+	details := workflows.CandidateDetails{
+		FullName: "John Smith",
+		SSN:      "111-11-1111",
+		DOB:      "1981-01-01",
+		Address:  "1 Chestnut Avenue",
+	}
+
+	env.OnActivity(a.SendEmploymentVerificationRequestEmail, mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, input *activities.SendEmploymentVerificationEmailInput) (*activities.SendEmploymentVerificationEmailResult, error) {
+			return &activities.SendEmploymentVerificationEmailResult{}, nil
+		},
+	)
+
+	env.ExecuteWorkflow(workflows.EmploymentVerification, &workflows.EmploymentVerificationWorkflowInput{CandidateDetails: details})
+
+	err := env.GetWorkflowError()
+	assert.Error(t, err)
+
+	var applicationErr *temporal.ApplicationError
+	assert.ErrorAs(t, err, &applicationErr)
+	assert.Equal(t, workflows.EmploymentVerificationTimedOutError, applicationErr.Type())
 }
@@ -1,52 +1,74 @@
 package workflows
 
 import (
-	"time"
-
 	"github.com/temporalio/background-checks/activities"
 	"go.temporal.io/sdk/workflow"
 )
 
 type FederalCriminalSearchWorkflowInput struct {
 	FullName       string
-	KnownAddresses []string
+	Aliases        []string
+	KnownAddresses []KnownAddress
 }
 
 type FederalCriminalSearchWorkflowResult struct {
 	Crimes []string
+	// Findings are the crimes along with the name each was found under.
+	Findings []NameFinding
+	// Completeness says whether every name and address was searched, see SearchCompletenessComplete.
+	Completeness string
+	// Gaps are the names and addresses that could not be searched.
+	Gaps []SearchGap
 }
 
 // @@@SNIPSTART background-checks-federal-criminal-workflow-definition
 func FederalCriminalSearch(ctx workflow.Context, input *FederalCriminalSearchWorkflowInput) (*FederalCriminalSearchWorkflowResult, error) {
-	var result activities.FederalCriminalSearchResult
+	var result FederalCriminalSearchWorkflowResult
+
+	logger := workflow.GetLogger(ctx)
 
-	name := input.FullName
-	var address string
+	names := candidateNames(input.FullName, input.Aliases)
+	// Federal courts are searched by district, so search from the candidate's latest address in each state they
+	// have lived in. If we have no addresses for them, search without one.
+	addresses := []string{""}
 	if len(input.KnownAddresses) > 0 {
-		address = input.KnownAddresses[0]
+		addresses = nil
+		for _, address := range latestAddressInEachState(input.KnownAddresses) {
+			addresses = append(addresses, address.String())
+		}
 	}
-	var crimes []string
 
-	activityInput := activities.FederalCriminalSearchInput{
-		FullName: name,
-		Address:  address,
-	}
-	var activityResult activities.FederalCriminalSearchResult
+	ctx = withVendorOptions(ctx, VendorFederalCourts)
 
-	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
-		StartToCloseTimeout: time.Minute,
-	})
+	// Search under each of the candidate's names, from each address, in parallel.
+	var federalchecks []workflow.Future
+	var searched []activities.FederalCriminalSearchInput
+	for _, name := range names {
+		for _, address := range addresses {
+			activityInput := activities.FederalCriminalSearchInput{
+				FullName: name,
+				Address:  address,
+			}
+			federalchecks = append(federalchecks, executeVendorActivity[activities.FederalCriminalSearchResult](ctx, a.FederalCriminalSearch, activityInput))
+			searched = append(searched, activityInput)
+		}
+	}
 
-	federalcheck := workflow.ExecuteActivity(ctx, a.FederalCriminalSearch, activityInput)
+	for i, federalcheck := range federalchecks {
+		var activityResult activities.FederalCriminalSearchResult
 
-	err := federalcheck.Get(ctx, &activityResult)
-	if err == nil {
-		crimes = append(crimes, activityResult.Crimes...)
+		err := federalcheck.Get(ctx, &activityResult)
+		if err != nil {
+			logger.Error("Federal criminal search failed", "name", searched[i].FullName, "address", searched[i].Address, "error", err)
+			result.Gaps = append(result.Gaps, newSearchGap(searched[i].FullName, searched[i].Address, err))
+			continue
+		}
+		result.Findings = addFindings(result.Findings, searched[i].FullName, activityResult.Crimes)
 	}
-	result.Crimes = crimes
+	result.Crimes = findingValues(result.Findings)
+	result.Completeness = searchCompleteness(len(searched), result.Gaps)
 
-	r := FederalCriminalSearchWorkflowResult(result)
-	return &r, nil
+	return &result, nil
 }
 
 // @@@SNIPEND
@@ -0,0 +1,102 @@
+package workflows_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/temporalio/background-checks/activities"
+	"github.com/temporalio/background-checks/workflows"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+)
+
+func TestFederalCriminalSearchWorkflowAliases(t *testing.T) {
+	s := testsuite.WorkflowTestSuite{}
+	env := s.NewTestWorkflowEnvironment()
+
+	// The activity has the same name as the workflow, which confuses the test environment's mocks,
+	// so a fake activity is registered in its place instead.
+	var searched []string
+	env.RegisterActivityWithOptions(
+		func(ctx context.Context, input *activities.FederalCriminalSearchInput) (*activities.FederalCriminalSearchResult, error) {
+			searched = append(searched, input.FullName)
+			switch input.FullName {
+			case "Jane Roe":
+				return &activities.FederalCriminalSearchResult{Crimes: []string{"Counterfeiting", "Espionage"}}, nil
+			case "Jane Q. Public":
+				return &activities.FederalCriminalSearchResult{Crimes: []string{"Espionage"}}, nil
+			default:
+				return &activities.FederalCriminalSearchResult{}, nil
+			}
+		},
+		activity.RegisterOptions{Name: "FederalCriminalSearch"},
+	)
+
+	env.ExecuteWorkflow(workflows.FederalCriminalSearch, &workflows.FederalCriminalSearchWorkflowInput{
+		FullName: "Jane Doe",
+		// Duplicates of the full name are not searched again.
+		Aliases: []string{"Jane Roe", "jane  doe", "Jane Q. Public"},
+		KnownAddresses: []workflows.KnownAddress{
+			{Address: "456 Oak Street", City: "Springfield", State: "IL", ZipCode: "62706"},
+		},
+	})
+
+	var result workflows.FederalCriminalSearchWorkflowResult
+	err := env.GetWorkflowResult(&result)
+	assert.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{"Jane Doe", "Jane Roe", "Jane Q. Public"}, searched)
+	assert.Equal(t, []string{"Counterfeiting", "Espionage"}, result.Crimes)
+	assert.Equal(t, []workflows.NameFinding{
+		{Name: "Jane Roe", Finding: "Counterfeiting"},
+		{Name: "Jane Roe", Finding: "Espionage"},
+	}, result.Findings)
+}
+
+func TestFederalCriminalSearchWorkflowIncomplete(t *testing.T) {
+	s := testsuite.WorkflowTestSuite{}
+	env := s.NewTestWorkflowEnvironment()
+
+	env.RegisterActivityWithOptions(
+		func(ctx context.Context, input *activities.FederalCriminalSearchInput) (*activities.FederalCriminalSearchResult, error) {
+			if input.Address == "1060 W. Addison St, Chicago, IL 60613" {
+				return nil, temporal.NewNonRetryableApplicationError("PACER unavailable", "VendorUnavailable", errors.New("unavailable"))
+			}
+			return &activities.FederalCriminalSearchResult{}, nil
+		},
+		activity.RegisterOptions{Name: "FederalCriminalSearch"},
+	)
+
+	env.ExecuteWorkflow(workflows.FederalCriminalSearch, &workflows.FederalCriminalSearchWorkflowInput{
+		FullName: "John Smith",
+		KnownAddresses: []workflows.KnownAddress{
+			{Address: "1 E. 161 St", City: "Bronx", State: "NY", ZipCode: "10451"},
+			{Address: "1060 W. Addison St", City: "Chicago", State: "IL", ZipCode: "60613"},
+		},
+	})
+
+	var result workflows.FederalCriminalSearchWorkflowResult
+	err := env.GetWorkflowResult(&result)
+	assert.NoError(t, err)
+
+	// A district that couldn't be searched must not be reported as clear.
+	assert.Empty(t, result.Crimes)
+	assert.Equal(t, workflows.SearchCompletenessIncomplete, result.Completeness)
+	assert.Len(t, result.Gaps, 1)
+	assert.Equal(t, "John Smith", result.Gaps[0].Name)
+	assert.Equal(t, "1060 W. Addison St, Chicago, IL 60613", result.Gaps[0].Jurisdiction)
+	assert.Equal(t, "VendorUnavailable", result.Gaps[0].Type)
+}
+
+func TestMergeAliases(t *testing.T) {
+	assert.Nil(t, workflows.MergeAliases("Jane Doe", nil, nil))
+	assert.Nil(t, workflows.MergeAliases("Jane Doe", []string{" ", ""}, []string{"JANE DOE"}))
+
+	assert.Equal(t,
+		[]string{"Jane Roe", "J. Doe"},
+		workflows.MergeAliases("Jane Doe", []string{" Jane  Roe "}, []string{"Jane Doe", "jane roe", "J. Doe"}),
+	)
+}
@@ -0,0 +1,158 @@
+package workflows
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/temporalio/background-checks/watchlist"
+	"go.temporal.io/sdk/workflow"
+)
+
+const (
+	// IdentityConfidenceThreshold is the score below which an identity is sent to a researcher for manual review.
+	IdentityConfidenceThreshold = 0.7
+
+	IdentityFlagNameMismatch         = "name_mismatch"
+	IdentityFlagDOBMismatch          = "dob_mismatch"
+	IdentityFlagSSNIssuedBeforeBirth = "ssn_issued_before_birth"
+	IdentityFlagAddressNotInHistory  = "address_not_in_history"
+
+	IdentitySourceAutomatic  = "automatic"
+	IdentitySourceResearcher = "researcher"
+)
+
+// identityFlagPenalties is how much each flag takes off an identity's score, which starts at 1.
+// An address missing from the trace history is common for recent moves, so it is not enough on its own
+// to send the identity for review.
+var identityFlagPenalties = map[string]float64{
+	IdentityFlagNameMismatch:         0.4,
+	IdentityFlagDOBMismatch:          0.4,
+	IdentityFlagSSNIssuedBeforeBirth: 0.5,
+	IdentityFlagAddressNotInHistory:  0.2,
+}
+
+type IdentityVerificationWorkflowInput struct {
+	CandidateDetails CandidateDetails
+	SSNTrace         SSNTraceWorkflowResult
+}
+
+type IdentityVerificationWorkflowResult struct {
+	// Score is between 0 and 1, and reflects how consistent the candidate's details are with the SSN record.
+	Score float64
+	// Flags are the inconsistencies that were found, such as IdentityFlagDOBMismatch.
+	Flags    []string
+	Verified bool
+	// Source says whether the identity was verified automatically or by a researcher.
+	Source string
+	Notes  string
+}
+
+// birthYear returns the year from a date of birth in the form YYYY-MM-DD.
+func birthYear(dob string) (int, bool) {
+	if len(dob) < 4 {
+		return 0, false
+	}
+	year, err := strconv.Atoi(dob[:4])
+	return year, err == nil
+}
+
+// ScoreIdentity compares the details the candidate gave us with the identity the SSN trace found.
+// Checks are skipped where either side is missing the information needed, so a thin SSN record is not
+// held against the candidate.
+func ScoreIdentity(details CandidateDetails, trace SSNTraceWorkflowResult) (float64, []string) {
+	var flags []string
+
+	if len(trace.NamesOnRecord) > 0 {
+		matched := false
+		for _, name := range trace.NamesOnRecord {
+			if watchlist.Score(details.FullName, name) >= watchlist.DefaultThreshold {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			flags = append(flags, IdentityFlagNameMismatch)
+		}
+	}
+
+	if details.DOB != "" && trace.DOBOnRecord != "" && details.DOB != trace.DOBOnRecord {
+		flags = append(flags, IdentityFlagDOBMismatch)
+	}
+
+	if year, ok := birthYear(details.DOB); ok && trace.IssuedYear > 0 && trace.IssuedYear < year {
+		flags = append(flags, IdentityFlagSSNIssuedBeforeBirth)
+	}
+
+	if details.Address != "" {
+		found := false
+		if current, err := ParseAddress(details.Address); err == nil {
+			for _, address := range trace.KnownAddresses {
+				if address.SamePlace(current) {
+					found = true
+					break
+				}
+			}
+		}
+		if !found {
+			flags = append(flags, IdentityFlagAddressNotInHistory)
+		}
+	}
+
+	score := 1.0
+	for _, flag := range flags {
+		score -= identityFlagPenalties[flag]
+	}
+
+	return math.Max(0, score), flags
+}
+
+// @@@SNIPSTART background-checks-identity-verification-workflow-definition
+
+// IdentityVerification is a Workflow Definition that checks the candidate is who they say they are, by comparing
+// their name, date of birth and address with the SSN trace. Identities that score below the
+// IdentityConfidenceThreshold are sent to a researcher, who decides if the details belong to the same person.
+// This is executed as a Child Workflow by the main Background Check, before any searches are started.
+func IdentityVerification(ctx workflow.Context, input *IdentityVerificationWorkflowInput) (*IdentityVerificationWorkflowResult, error) {
+	var result IdentityVerificationWorkflowResult
+
+	result.Score, result.Flags = ScoreIdentity(input.CandidateDetails, input.SSNTrace)
+
+	if result.Score >= IdentityConfidenceThreshold {
+		result.Verified = true
+		result.Source = IdentitySourceAutomatic
+		return &result, nil
+	}
+
+	review := startResearcherReview(ctx, "identity", ResearcherReviewWorkflowInput{
+		Skill:    ResearcherSkillIdentity,
+		Subject:  fmt.Sprintf("Identity verification for %s", input.CandidateDetails.FullName),
+		Question: "Do the candidate's details and the SSN record belong to the same person?",
+		Details: map[string]string{
+			"Candidate Name":    input.CandidateDetails.FullName,
+			"Candidate DOB":     input.CandidateDetails.DOB,
+			"Candidate Address": input.CandidateDetails.Address,
+			"Names on Record":   strings.Join(input.SSNTrace.NamesOnRecord, "; "),
+			"DOB on Record":     input.SSNTrace.DOBOnRecord,
+			"SSN Issued":        strconv.Itoa(input.SSNTrace.IssuedYear),
+			"Address History":   formatAddresses(input.SSNTrace.KnownAddresses),
+			"Flags":             strings.Join(result.Flags, ", "),
+			"Score":             fmt.Sprintf("%.0f%%", result.Score*100),
+		},
+	})
+
+	var r ResearcherReviewWorkflowResult
+	err := review.Get(ctx, &r)
+	if err != nil {
+		return &result, err
+	}
+
+	result.Verified = r.Confirmed
+	result.Source = IdentitySourceResearcher
+	result.Notes = r.Notes
+
+	return &result, nil
+}
+
+// @@@SNIPEND
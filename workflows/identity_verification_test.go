@@ -0,0 +1,102 @@
+package workflows_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/temporalio/background-checks/workflows"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+)
+
+func TestScoreIdentity(t *testing.T) {
+	trace := workflows.SSNTraceWorkflowResult{
+		SSNIsValid: true,
+		KnownAddresses: []workflows.KnownAddress{
+			{Address: "123 Broadway", City: "New York", State: "NY", ZipCode: "10011"},
+			{Address: "1 E. 161 St", City: "Bronx", State: "NY", ZipCode: "10451"},
+		},
+		NamesOnRecord: []string{"John Smith"},
+		DOBOnRecord:   "1981-07-22",
+		IssuedYear:    1981,
+	}
+
+	tests := []struct {
+		name    string
+		details workflows.CandidateDetails
+		flags   []string
+		review  bool
+	}{
+		{
+			name:    "consistent",
+			details: workflows.CandidateDetails{FullName: "John Smith", DOB: "1981-07-22", Address: "1 E 161 St, Bronx, NY 10451"},
+		},
+		{
+			name:    "name with middle initial",
+			details: workflows.CandidateDetails{FullName: "Smith, John", DOB: "1981-07-22"},
+		},
+		{
+			name:    "new address",
+			details: workflows.CandidateDetails{FullName: "John Smith", DOB: "1981-07-22", Address: "4 Jersey St, Boston, MA 02215"},
+			flags:   []string{workflows.IdentityFlagAddressNotInHistory},
+		},
+		{
+			name:    "different date of birth",
+			details: workflows.CandidateDetails{FullName: "John Smith", DOB: "1990-01-01"},
+			flags:   []string{workflows.IdentityFlagDOBMismatch, workflows.IdentityFlagSSNIssuedBeforeBirth},
+			review:  true,
+		},
+		{
+			name:    "different person",
+			details: workflows.CandidateDetails{FullName: "Alex Garcia", DOB: "1981-07-22"},
+			flags:   []string{workflows.IdentityFlagNameMismatch},
+			review:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, flags := workflows.ScoreIdentity(tt.details, trace)
+			assert.Equal(t, tt.flags, flags)
+			assert.Equal(t, tt.review, score < workflows.IdentityConfidenceThreshold)
+		})
+	}
+}
+
+func TestIdentityVerificationWorkflowReview(t *testing.T) {
+	s := testsuite.WorkflowTestSuite{}
+	env := s.NewTestWorkflowEnvironment()
+
+	env.RegisterWorkflow(workflows.ResearcherReview)
+
+	var review workflows.ResearcherReviewWorkflowInput
+	env.OnWorkflow(workflows.ResearcherReview, mock.Anything, mock.Anything).Return(
+		func(ctx workflow.Context, input *workflows.ResearcherReviewWorkflowInput) (*workflows.ResearcherReviewWorkflowResult, error) {
+			review = *input
+			return &workflows.ResearcherReviewWorkflowResult{Confirmed: true, Notes: "DOB typo confirmed with candidate"}, nil
+		},
+	)
+
+	env.ExecuteWorkflow(workflows.IdentityVerification, &workflows.IdentityVerificationWorkflowInput{
+		CandidateDetails: workflows.CandidateDetails{FullName: "Alex Garcia", DOB: "1975-03-14"},
+		SSNTrace: workflows.SSNTraceWorkflowResult{
+			SSNIsValid:    true,
+			NamesOnRecord: []string{"Alex Garcia"},
+			DOBOnRecord:   "1985-03-14",
+			IssuedYear:    1979,
+		},
+	})
+
+	var result workflows.IdentityVerificationWorkflowResult
+	err := env.GetWorkflowResult(&result)
+	assert.NoError(t, err)
+
+	assert.Equal(t, workflows.ResearcherSkillIdentity, review.Skill)
+	assert.Equal(t, workflows.IdentityFlagDOBMismatch, review.Details["Flags"])
+
+	assert.True(t, result.Verified)
+	assert.Equal(t, workflows.IdentitySourceResearcher, result.Source)
+	assert.Equal(t, "DOB typo confirmed with candidate", result.Notes)
+	assert.InDelta(t, 0.6, result.Score, 0.001)
+}
@@ -0,0 +1,215 @@
+package workflows
+
+import (
+	"strings"
+	"time"
+
+	"github.com/temporalio/background-checks/activities"
+	"go.temporal.io/sdk/workflow"
+)
+
+const (
+	CountrySearchStatusComplete    = "complete"
+	CountrySearchStatusUnavailable = "unavailable"
+	CountrySearchStatusTimedOut    = "timed_out"
+	CountrySearchStatusFailed      = "failed"
+)
+
+// countryTurnaround is how long criminal record searches take in a country, and how long we wait before giving up.
+type countryTurnaround struct {
+	Expected time.Duration
+	Deadline time.Duration
+}
+
+// countryTurnarounds lists the countries our international vendor can search.
+// Searches in any other country are reported as unavailable.
+var countryTurnarounds = map[string]countryTurnaround{
+	"AU": {Expected: time.Hour * 24 * 3, Deadline: time.Hour * 24 * 7},
+	"CA": {Expected: time.Hour * 24 * 2, Deadline: time.Hour * 24 * 5},
+	"DE": {Expected: time.Hour * 24 * 5, Deadline: time.Hour * 24 * 10},
+	"FR": {Expected: time.Hour * 24 * 5, Deadline: time.Hour * 24 * 10},
+	"GB": {Expected: time.Hour * 24 * 3, Deadline: time.Hour * 24 * 7},
+	"IN": {Expected: time.Hour * 24 * 10, Deadline: time.Hour * 24 * 21},
+	"JP": {Expected: time.Hour * 24 * 7, Deadline: time.Hour * 24 * 14},
+	"MX": {Expected: time.Hour * 24 * 7, Deadline: time.Hour * 24 * 14},
+}
+
+// NormalizeCountries tidies up the countries a candidate has given us, dropping blanks and duplicates.
+func NormalizeCountries(countries []string) []string {
+	var result []string
+	seen := make(map[string]bool)
+
+	for _, c := range countries {
+		c = strings.ToUpper(strings.TrimSpace(c))
+		if c == "" || seen[c] {
+			continue
+		}
+		seen[c] = true
+		result = append(result, c)
+	}
+
+	return result
+}
+
+type InternationalCriminalSearchWorkflowInput struct {
+	FullName  string
+	Aliases   []string
+	Countries []string
+}
+
+type InternationalCriminalSearchWorkflowResult struct {
+	Countries []CountryCriminalSearchWorkflowResult
+}
+
+type CountryCriminalSearchWorkflowInput struct {
+	FullName string
+	Aliases  []string
+	Country  string
+	Expected time.Duration
+	Deadline time.Duration
+}
+
+type CountryCriminalSearchWorkflowResult struct {
+	Country string
+	Status  string
+	Crimes  []string
+	// Findings are the crimes along with the name each was found under.
+	Findings []NameFinding
+	// ExpectedBy is when the search was expected to complete, based on the country's usual turnaround.
+	ExpectedBy  time.Time
+	CompletedAt time.Time
+	Error       string
+}
+
+// @@@SNIPSTART background-checks-country-criminal-search-workflow-definition
+
+// CountryCriminalSearch is a Workflow Definition that searches the criminal records of a single country, under each
+// of the candidate's names. Searches abroad can take days, so the vendor is polled until the results are ready,
+// or the country's deadline passes.
+// This is executed as a Child Workflow by InternationalCriminalSearch.
+func CountryCriminalSearch(ctx workflow.Context, input *CountryCriminalSearchWorkflowInput) (*CountryCriminalSearchWorkflowResult, error) {
+	result := CountryCriminalSearchWorkflowResult{
+		Country:    input.Country,
+		ExpectedBy: workflow.Now(ctx).Add(input.Expected),
+	}
+
+	ctx, cancel := workflow.WithCancel(ctx)
+	defer cancel()
+
+	// The vendor is polled by retrying the activity until the search is complete.
+	actx := withVendorOptions(ctx, VendorInternational)
+
+	s := workflow.NewSelector(ctx)
+
+	names := candidateNames(input.FullName, input.Aliases)
+	pending := len(names)
+
+	for _, name := range names {
+		name := name
+		s.AddFuture(executeVendorActivity[activities.CountryCriminalRecordSearchResult](actx, a.CountryCriminalRecordSearch, activities.CountryCriminalRecordSearchInput{
+			FullName: name,
+			Country:  input.Country,
+		}), func(f workflow.Future) {
+			pending--
+
+			var r activities.CountryCriminalRecordSearchResult
+
+			err := f.Get(ctx, &r)
+			if err != nil {
+				result.Status = CountrySearchStatusFailed
+				result.Error = err.Error()
+				return
+			}
+
+			if !r.Available {
+				result.Status = CountrySearchStatusUnavailable
+				return
+			}
+
+			result.Findings = addFindings(result.Findings, name, r.Crimes)
+		})
+	}
+	s.AddFuture(workflow.NewTimer(ctx, input.Deadline), func(f workflow.Future) {
+		result.Status = CountrySearchStatusTimedOut
+	})
+
+	// Wait for the search under every name to complete. A failed, unavailable or timed out search ends the wait,
+	// as the country can't be reported as complete.
+	for pending > 0 && result.Status == "" {
+		s.Select(ctx)
+	}
+
+	if result.Status == CountrySearchStatusTimedOut || result.Status == CountrySearchStatusFailed {
+		return &result, nil
+	}
+
+	result.CompletedAt = workflow.Now(ctx)
+	if result.Status == "" {
+		result.Status = CountrySearchStatusComplete
+		result.Crimes = findingValues(result.Findings)
+	}
+
+	return &result, nil
+}
+
+// @@@SNIPEND
+
+// @@@SNIPSTART background-checks-international-criminal-search-workflow-definition
+
+// InternationalCriminalSearch is a Workflow Definition that searches the criminal records of each country the
+// candidate has lived in outside the US. Each country is searched by its own CountryCriminalSearch Child Workflow,
+// as each has its own turnaround. Countries our vendor can't search are reported as unavailable.
+// This is executed as a Child Workflow by the main Background Check.
+func InternationalCriminalSearch(ctx workflow.Context, input *InternationalCriminalSearchWorkflowInput) (*InternationalCriminalSearchWorkflowResult, error) {
+	var result InternationalCriminalSearchWorkflowResult
+
+	logger := workflow.GetLogger(ctx)
+	parentID := workflow.GetInfo(ctx).WorkflowExecution.ID
+
+	// Countries may have come from somewhere other than our own forms, so tidy them up here as well, otherwise
+	// lowercase codes would be reported as unavailable and duplicates would start the same child twice.
+	countries := NormalizeCountries(input.Countries)
+
+	result.Countries = make([]CountryCriminalSearchWorkflowResult, len(countries))
+	futures := make(map[int]workflow.ChildWorkflowFuture)
+
+	for i, country := range countries {
+		turnaround, ok := countryTurnarounds[country]
+		if !ok {
+			result.Countries[i] = CountryCriminalSearchWorkflowResult{Country: country, Status: CountrySearchStatusUnavailable}
+			continue
+		}
+
+		futures[i] = workflow.ExecuteChildWorkflow(
+			workflow.WithChildOptions(ctx, workflow.ChildWorkflowOptions{
+				WorkflowID:          CountryCriminalSearchWorkflowID(parentID, country),
+				WaitForCancellation: true,
+			}),
+			CountryCriminalSearch,
+			CountryCriminalSearchWorkflowInput{
+				FullName: input.FullName,
+				Aliases:  input.Aliases,
+				Country:  country,
+				Expected: turnaround.Expected,
+				Deadline: turnaround.Deadline,
+			},
+		)
+	}
+
+	for i, country := range countries {
+		f, ok := futures[i]
+		if !ok {
+			continue
+		}
+
+		err := f.Get(ctx, &result.Countries[i])
+		if err != nil {
+			logger.Error("Country criminal search failed", "country", country, "error", err)
+			result.Countries[i] = CountryCriminalSearchWorkflowResult{Country: country, Status: CountrySearchStatusFailed, Error: err.Error()}
+		}
+	}
+
+	return &result, nil
+}
+
+// @@@SNIPEND
@@ -0,0 +1,135 @@
+package workflows_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/temporalio/background-checks/activities"
+	"github.com/temporalio/background-checks/workflows"
+	"go.temporal.io/sdk/testsuite"
+)
+
+func TestInternationalCriminalSearchWorkflow(t *testing.T) {
+	s := testsuite.WorkflowTestSuite{}
+	env := s.NewTestWorkflowEnvironment()
+	var a *activities.Activities
+
+	env.RegisterWorkflow(workflows.CountryCriminalSearch)
+
+	env.OnActivity(a.CountryCriminalRecordSearch, mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, input *activities.CountryCriminalRecordSearchInput) (*activities.CountryCriminalRecordSearchResult, error) {
+			switch input.Country {
+			case "GB":
+				return &activities.CountryCriminalRecordSearchResult{Available: true, Crimes: []string{"Fraud"}}, nil
+			default:
+				// The vendor has stopped covering this country.
+				return &activities.CountryCriminalRecordSearchResult{Available: false}, nil
+			}
+		},
+	)
+
+	env.ExecuteWorkflow(workflows.InternationalCriminalSearch, &workflows.InternationalCriminalSearchWorkflowInput{
+		FullName:  "John Smith",
+		Countries: []string{"GB", "ZZ", "DE"},
+	})
+
+	var result workflows.InternationalCriminalSearchWorkflowResult
+	err := env.GetWorkflowResult(&result)
+	assert.NoError(t, err)
+
+	assert.Len(t, result.Countries, 3)
+	assert.Equal(t, "GB", result.Countries[0].Country)
+	assert.Equal(t, workflows.CountrySearchStatusComplete, result.Countries[0].Status)
+	assert.Equal(t, []string{"Fraud"}, result.Countries[0].Crimes)
+	assert.Equal(t, "ZZ", result.Countries[1].Country)
+	assert.Equal(t, workflows.CountrySearchStatusUnavailable, result.Countries[1].Status)
+	assert.Equal(t, "DE", result.Countries[2].Country)
+	assert.Equal(t, workflows.CountrySearchStatusUnavailable, result.Countries[2].Status)
+}
+
+func TestInternationalCriminalSearchWorkflowNormalizesCountries(t *testing.T) {
+	s := testsuite.WorkflowTestSuite{}
+	env := s.NewTestWorkflowEnvironment()
+	var a *activities.Activities
+
+	env.RegisterWorkflow(workflows.CountryCriminalSearch)
+
+	env.OnActivity(a.CountryCriminalRecordSearch, mock.Anything, mock.Anything).Return(
+		&activities.CountryCriminalRecordSearchResult{Available: true}, nil,
+	).Once()
+
+	env.ExecuteWorkflow(workflows.InternationalCriminalSearch, &workflows.InternationalCriminalSearchWorkflowInput{
+		FullName:  "John Smith",
+		Countries: []string{"gb", " GB"},
+	})
+
+	var result workflows.InternationalCriminalSearchWorkflowResult
+	err := env.GetWorkflowResult(&result)
+	assert.NoError(t, err)
+
+	assert.Len(t, result.Countries, 1)
+	assert.Equal(t, "GB", result.Countries[0].Country)
+	assert.Equal(t, workflows.CountrySearchStatusComplete, result.Countries[0].Status)
+	env.AssertExpectations(t)
+}
+
+func TestCountryCriminalSearchWorkflowTimesOut(t *testing.T) {
+	s := testsuite.WorkflowTestSuite{}
+	env := s.NewTestWorkflowEnvironment()
+	var a *activities.Activities
+
+	env.OnActivity(a.CountryCriminalRecordSearch, mock.Anything, mock.Anything).Return(
+		&activities.CountryCriminalRecordSearchResult{Available: true}, nil,
+	).After(time.Hour * 24 * 30)
+
+	env.ExecuteWorkflow(workflows.CountryCriminalSearch, &workflows.CountryCriminalSearchWorkflowInput{
+		FullName: "John Smith",
+		Country:  "IN",
+		Expected: time.Hour * 24 * 10,
+		Deadline: time.Hour * 24 * 21,
+	})
+
+	var result workflows.CountryCriminalSearchWorkflowResult
+	err := env.GetWorkflowResult(&result)
+	assert.NoError(t, err)
+	assert.Equal(t, workflows.CountrySearchStatusTimedOut, result.Status)
+	assert.True(t, result.CompletedAt.IsZero())
+}
+
+func TestNormalizeCountries(t *testing.T) {
+	assert.Equal(t, []string{"GB", "CA"}, workflows.NormalizeCountries([]string{" gb", "CA", "", "GB "}))
+}
+
+func TestCountryCriminalSearchWorkflowAliases(t *testing.T) {
+	s := testsuite.WorkflowTestSuite{}
+	env := s.NewTestWorkflowEnvironment()
+	var a *activities.Activities
+
+	env.OnActivity(a.CountryCriminalRecordSearch, mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, input *activities.CountryCriminalRecordSearchInput) (*activities.CountryCriminalRecordSearchResult, error) {
+			if input.FullName == "Juan Garcia" {
+				return &activities.CountryCriminalRecordSearchResult{Available: true, Crimes: []string{"Fraud"}}, nil
+			}
+			return &activities.CountryCriminalRecordSearchResult{Available: true}, nil
+		},
+	)
+
+	env.ExecuteWorkflow(workflows.CountryCriminalSearch, &workflows.CountryCriminalSearchWorkflowInput{
+		FullName: "John Garcia",
+		Aliases:  []string{"Juan Garcia"},
+		Country:  "MX",
+		Expected: time.Hour * 24,
+		Deadline: time.Hour * 24 * 7,
+	})
+
+	var result workflows.CountryCriminalSearchWorkflowResult
+	err := env.GetWorkflowResult(&result)
+	assert.NoError(t, err)
+
+	assert.Equal(t, workflows.CountrySearchStatusComplete, result.Status)
+	assert.Equal(t, []string{"Fraud"}, result.Crimes)
+	assert.Equal(t, []workflows.NameFinding{{Name: "Juan Garcia", Finding: "Fraud"}}, result.Findings)
+}
@@ -1,50 +1,69 @@
 package workflows
 
 import (
-	"time"
-
 	"github.com/temporalio/background-checks/activities"
 	"go.temporal.io/sdk/workflow"
 )
 
 type MotorVehicleIncidentSearchWorkflowInput struct {
 	FullName string
-	Address  string
+	Aliases  []string
+	// Address is the candidate's current address, if we know it.
+	Address *KnownAddress
 }
 
 type MotorVehicleIncidentSearchWorkflowResult struct {
 	LicenseValid          bool
 	MotorVehicleIncidents []string
+	// Findings are the incidents along with the name each was found under.
+	Findings []NameFinding
+	// Completeness says whether the DMV records were searched under every name, see SearchCompletenessComplete.
+	// LicenseValid is only reliable if the search is complete.
+	Completeness string
+	// Gaps are the names that could not be searched.
+	Gaps []SearchGap
 }
 
 // @@@SNIPSTART background-checks-motor-vehicle-workflow-definition
 func MotorVehicleIncidentSearch(ctx workflow.Context, input *MotorVehicleIncidentSearchWorkflowInput) (*MotorVehicleIncidentSearchWorkflowResult, error) {
 	var result MotorVehicleIncidentSearchWorkflowResult
 
-	name := input.FullName
-	address := input.Address
-	var motorvehicleIncidents []string
+	logger := workflow.GetLogger(ctx)
 
-	activityInput := activities.MotorVehicleIncidentSearchInput{
-		FullName: name,
-		Address:  address,
+	names := candidateNames(input.FullName, input.Aliases)
+	var address string
+	if input.Address != nil {
+		address = input.Address.String()
 	}
-	var activityResult activities.MotorVehicleIncidentSearchResult
 
-	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
-		StartToCloseTimeout: time.Minute,
-	})
+	ctx = withVendorOptions(ctx, VendorDMV)
+
+	// Search under each of the candidate's names in parallel. The candidate's license may be held under any of them.
+	motorvehicleIncidentSearches := make([]workflow.Future, len(names))
+	for i, name := range names {
+		activityInput := activities.MotorVehicleIncidentSearchInput{
+			FullName: name,
+			Address:  address,
+		}
+		motorvehicleIncidentSearches[i] = executeVendorActivity[activities.MotorVehicleIncidentSearchResult](ctx, a.MotorVehicleIncidentSearch, activityInput)
+	}
 
-	motorvehicleIncidentSearch := workflow.ExecuteActivity(ctx, a.MotorVehicleIncidentSearch, activityInput)
+	for i, name := range names {
+		var activityResult activities.MotorVehicleIncidentSearchResult
 
-	err := motorvehicleIncidentSearch.Get(ctx, &activityResult)
-	if err == nil {
-		motorvehicleIncidents = append(motorvehicleIncidents, activityResult.MotorVehicleIncidents...)
+		err := motorvehicleIncidentSearches[i].Get(ctx, &activityResult)
+		if err != nil {
+			logger.Error("Motor vehicle incident search failed", "name", name, "error", err)
+			result.Gaps = append(result.Gaps, newSearchGap(name, address, err))
+			continue
+		}
+		result.LicenseValid = result.LicenseValid || activityResult.LicenseValid
+		result.Findings = addFindings(result.Findings, name, activityResult.MotorVehicleIncidents)
 	}
-	result.MotorVehicleIncidents = motorvehicleIncidents
+	result.MotorVehicleIncidents = findingValues(result.Findings)
+	result.Completeness = searchCompleteness(len(names), result.Gaps)
 
-	r := MotorVehicleIncidentSearchWorkflowResult(result)
-	return &r, nil
+	return &result, nil
 }
 
 // @@@SNIPEND
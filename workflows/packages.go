@@ -0,0 +1,351 @@
+package workflows
+
+import (
+	"fmt"
+	"time"
+
+	"go.temporal.io/sdk/workflow"
+)
+
+// SearchDefinition describes one of the searches that can make up a background check.
+type SearchDefinition struct {
+	// Name is used as the key for the search's results and errors in the report.
+	Name string
+	// Workflow is the child workflow that performs the search.
+	Workflow interface{}
+	// Input builds the input for the child workflow from the state of the background check.
+	Input func(state *BackgroundCheckState) interface{}
+	// Condition decides if the search should run for a particular candidate. A nil Condition always runs the search.
+	Condition func(state *BackgroundCheckState) bool
+	// Refusal, if set, returns a reason the search must not be run for this candidate, such as missing consent.
+	// Unlike a search skipped by its Condition, a refused search is listed in the report along with the reason.
+	Refusal func(state *BackgroundCheckState) string
+	// OnResult, if set, is called once the search completes successfully so that the result can be recorded in the
+	// state in its typed form, as well as in SearchResults.
+	OnResult func(ctx workflow.Context, state *BackgroundCheckState, f workflow.Future) error
+	// ReinvestigationSkill, if set, is the researcher skill used to re-verify a disputed finding by hand. It is set
+	// for searches that can't be re-run without side effects, such as contacting references again.
+	ReinvestigationSkill string
+}
+
+// SearchPackage is a named, ordered list of searches that a hiring manager can request for a candidate.
+type SearchPackage struct {
+	Name        string
+	Description string
+	Searches    []SearchDefinition
+	// SLA is how long the package's searches should take to complete, and what happens if they take longer.
+	SLA TurnaroundSLA
+}
+
+var federalCriminalSearch = SearchDefinition{
+	Name:     "FederalCriminalSearch",
+	Workflow: FederalCriminalSearch,
+	Input: func(state *BackgroundCheckState) interface{} {
+		return FederalCriminalSearchWorkflowInput{FullName: state.CandidateDetails.FullName, Aliases: state.CandidateDetails.Aliases, KnownAddresses: state.SSNTrace.KnownAddresses}
+	},
+}
+
+var stateCriminalSearch = SearchDefinition{
+	Name:     "StateCriminalSearch",
+	Workflow: StateCriminalSearch,
+	Input: func(state *BackgroundCheckState) interface{} {
+		return StateCriminalSearchWorkflowInput{FullName: state.CandidateDetails.FullName, Aliases: state.CandidateDetails.Aliases, KnownAddresses: state.SSNTrace.KnownAddresses}
+	},
+}
+
+var countyCriminalSearches = SearchDefinition{
+	Name:     "CountyCriminalSearches",
+	Workflow: CountyCriminalSearches,
+	Input: func(state *BackgroundCheckState) interface{} {
+		return CountyCriminalSearchesWorkflowInput{FullName: state.CandidateDetails.FullName, Aliases: state.CandidateDetails.Aliases, KnownAddresses: state.SSNTrace.KnownAddresses}
+	},
+}
+
+var motorVehicleIncidentSearch = SearchDefinition{
+	Name:     "MotorVehicleIncidentSearch",
+	Workflow: MotorVehicleIncidentSearch,
+	Input: func(state *BackgroundCheckState) interface{} {
+		// The address history is ordered with the current address first.
+		var primaryAddress *KnownAddress
+		if len(state.SSNTrace.KnownAddresses) > 0 {
+			primaryAddress = &state.SSNTrace.KnownAddresses[0]
+		}
+		return MotorVehicleIncidentSearchWorkflowInput{FullName: state.CandidateDetails.FullName, Aliases: state.CandidateDetails.Aliases, Address: primaryAddress}
+	},
+}
+
+var employmentVerification = SearchDefinition{
+	Name:     "EmploymentVerification",
+	Workflow: EmploymentVerification,
+	Input: func(state *BackgroundCheckState) interface{} {
+		return EmploymentVerificationWorkflowInput{CandidateDetails: state.CandidateDetails}
+	},
+	// Verify their employment if they provided an employer
+	Condition: func(state *BackgroundCheckState) bool {
+		return state.CandidateDetails.Employer != ""
+	},
+}
+
+var educationVerification = SearchDefinition{
+	Name:     "EducationVerification",
+	Workflow: EducationVerification,
+	Input: func(state *BackgroundCheckState) interface{} {
+		return EducationVerificationWorkflowInput{FullName: state.CandidateDetails.FullName, Aliases: state.CandidateDetails.Aliases, Education: state.CandidateDetails.Education}
+	},
+	// Verify their education if they told us about any qualifications
+	Condition: func(state *BackgroundCheckState) bool {
+		return len(state.CandidateDetails.Education) > 0
+	},
+}
+
+var professionalLicenseSearch = SearchDefinition{
+	Name:     "ProfessionalLicenseSearch",
+	Workflow: ProfessionalLicenseSearch,
+	Input: func(state *BackgroundCheckState) interface{} {
+		return ProfessionalLicenseSearchWorkflowInput{FullName: state.CandidateDetails.FullName, Licenses: state.CandidateDetails.Licenses}
+	},
+	// Verify their licenses if they told us about any
+	Condition: func(state *BackgroundCheckState) bool {
+		return len(state.CandidateDetails.Licenses) > 0
+	},
+	OnResult: func(ctx workflow.Context, state *BackgroundCheckState, f workflow.Future) error {
+		var r ProfessionalLicenseSearchWorkflowResult
+		err := f.Get(ctx, &r)
+		state.ProfessionalLicenses = r.Licenses
+		return err
+	},
+}
+
+var sexOffenderRegistrySearch = SearchDefinition{
+	Name:     "SexOffenderRegistrySearch",
+	Workflow: SexOffenderRegistrySearch,
+	Input: func(state *BackgroundCheckState) interface{} {
+		return SexOffenderRegistrySearchWorkflowInput{FullName: state.CandidateDetails.FullName, Aliases: state.CandidateDetails.Aliases, DOB: state.CandidateDetails.DOB}
+	},
+	// Registry entries are matched on name and date of birth, so we can't search without their date of birth
+	Condition: func(state *BackgroundCheckState) bool {
+		return state.CandidateDetails.DOB != ""
+	},
+}
+
+var internationalCriminalSearch = SearchDefinition{
+	Name:     "InternationalCriminalSearch",
+	Workflow: InternationalCriminalSearch,
+	Input: func(state *BackgroundCheckState) interface{} {
+		return InternationalCriminalSearchWorkflowInput{FullName: state.CandidateDetails.FullName, Aliases: state.CandidateDetails.Aliases, Countries: state.CandidateDetails.Countries}
+	},
+	// Search abroad if they have lived outside the US
+	Condition: func(state *BackgroundCheckState) bool {
+		return len(state.CandidateDetails.Countries) > 0
+	},
+}
+
+var watchlistSearch = SearchDefinition{
+	Name:     "WatchlistSearch",
+	Workflow: WatchlistSearch,
+	Input: func(state *BackgroundCheckState) interface{} {
+		return WatchlistSearchWorkflowInput{FullName: state.CandidateDetails.FullName, Aliases: state.CandidateDetails.Aliases}
+	},
+}
+
+var referenceCheck = SearchDefinition{
+	Name:     "ReferenceCheck",
+	Workflow: ReferenceCheck,
+	Input: func(state *BackgroundCheckState) interface{} {
+		return ReferenceCheckWorkflowInput{CandidateName: state.CandidateDetails.FullName, References: state.CandidateDetails.References}
+	},
+	// Contact their references if they gave us any
+	Condition: func(state *BackgroundCheckState) bool {
+		return len(state.CandidateDetails.References) > 0
+	},
+	OnResult: func(ctx workflow.Context, state *BackgroundCheckState, f workflow.Future) error {
+		var r ReferenceCheckWorkflowResult
+		err := f.Get(ctx, &r)
+		state.References = r.References
+		return err
+	},
+	// Re-running the search would contact the references again.
+	ReinvestigationSkill: ResearcherSkillEmployment,
+}
+
+var creditReportSearch = SearchDefinition{
+	Name:     "CreditReportSearch",
+	Workflow: CreditReportSearch,
+	Input: func(state *BackgroundCheckState) interface{} {
+		return CreditReportSearchWorkflowInput{
+			FullName: state.CandidateDetails.FullName,
+			SSN:      state.CandidateDetails.SSN,
+			DOB:      state.CandidateDetails.DOB,
+			Consent:  state.CreditConsent,
+		}
+	},
+	// A credit report is only permitted with the candidate's separate consent
+	Refusal: func(state *BackgroundCheckState) string {
+		if !state.CreditConsent {
+			return CreditConsentMissingReason
+		}
+		return ""
+	},
+	OnResult: func(ctx workflow.Context, state *BackgroundCheckState, f workflow.Future) error {
+		var r CreditReportSearchWorkflowResult
+		err := f.Get(ctx, &r)
+		state.CreditReport = &r
+		return err
+	},
+}
+
+var drugScreen = SearchDefinition{
+	Name:     "DrugScreen",
+	Workflow: DrugScreen,
+	Input: func(state *BackgroundCheckState) interface{} {
+		return DrugScreenWorkflowInput{FullName: state.CandidateDetails.FullName, Email: state.Email}
+	},
+	// Re-running the search would order another lab test.
+	ReinvestigationSkill: ResearcherSkillDrugScreen,
+}
+
+// searchPackages is the registry of packages available to hiring managers.
+var searchPackages = []SearchPackage{
+	{
+		Name:        "standard",
+		Description: "Federal criminal search",
+		Searches: []SearchDefinition{
+			federalCriminalSearch,
+		},
+		SLA: TurnaroundSLA{
+			Warnings:             []time.Duration{time.Hour * 24 * 2, time.Hour * 24 * 3},
+			Deadline:             time.Hour * 24 * 5,
+			ReleasePartialReport: true,
+		},
+	},
+	{
+		Name:        "driver",
+		Description: "Federal criminal and motor vehicle incident searches and drug screen",
+		Searches: []SearchDefinition{
+			federalCriminalSearch,
+			motorVehicleIncidentSearch,
+			drugScreen,
+		},
+		SLA: TurnaroundSLA{
+			Warnings:             []time.Duration{time.Hour * 24 * 5, time.Hour * 24 * 7},
+			Deadline:             time.Hour * 24 * 10,
+			ReleasePartialReport: true,
+		},
+	},
+	{
+		Name:        "full",
+		Description: "Federal and state criminal searches, motor vehicle incident search and employment verification",
+		Searches: []SearchDefinition{
+			federalCriminalSearch,
+			stateCriminalSearch,
+			motorVehicleIncidentSearch,
+			employmentVerification,
+		},
+		SLA: TurnaroundSLA{
+			Warnings:             []time.Duration{time.Hour * 24 * 10, time.Hour * 24 * 15},
+			Deadline:             time.Hour * 24 * 21,
+			ReleasePartialReport: true,
+		},
+	},
+	{
+		// The searches added since the full package was defined are offered here, so that existing full checks
+		// aren't changed under the Hiring Managers who ordered them.
+		Name:        "extended",
+		Description: "Everything in the full package, plus county and international criminal searches, education verification, reference checks and watch list screening",
+		Searches: []SearchDefinition{
+			federalCriminalSearch,
+			stateCriminalSearch,
+			countyCriminalSearches,
+			internationalCriminalSearch,
+			motorVehicleIncidentSearch,
+			employmentVerification,
+			educationVerification,
+			referenceCheck,
+			watchlistSearch,
+		},
+		SLA: TurnaroundSLA{
+			Warnings:             []time.Duration{time.Hour * 24 * 10, time.Hour * 24 * 15},
+			Deadline:             time.Hour * 24 * 21,
+			ReleasePartialReport: true,
+		},
+	},
+	{
+		Name:        "professional",
+		Description: "Federal and state criminal searches, employment verification, professional license verification and reference checks",
+		Searches: []SearchDefinition{
+			federalCriminalSearch,
+			stateCriminalSearch,
+			employmentVerification,
+			professionalLicenseSearch,
+			referenceCheck,
+		},
+		SLA: TurnaroundSLA{
+			Warnings:             []time.Duration{time.Hour * 24 * 5, time.Hour * 24 * 7},
+			Deadline:             time.Hour * 24 * 10,
+			ReleasePartialReport: true,
+		},
+	},
+	{
+		Name:        "care",
+		Description: "Federal, state and county criminal searches, sex offender registry search and employment verification, for roles working with children or vulnerable adults",
+		Searches: []SearchDefinition{
+			federalCriminalSearch,
+			stateCriminalSearch,
+			countyCriminalSearches,
+			sexOffenderRegistrySearch,
+			employmentVerification,
+		},
+		// A candidate can't be cleared to work with vulnerable people on a partial report, so it is never released early.
+		SLA: TurnaroundSLA{
+			Warnings:             []time.Duration{time.Hour * 24 * 5, time.Hour * 24 * 7},
+			Deadline:             time.Hour * 24 * 10,
+			ReleasePartialReport: false,
+		},
+	},
+	{
+		Name:        "finance",
+		Description: "Federal, state and county criminal searches, employment verification, employment credit report and watch list screening, for roles handling money",
+		Searches: []SearchDefinition{
+			federalCriminalSearch,
+			stateCriminalSearch,
+			countyCriminalSearches,
+			employmentVerification,
+			creditReportSearch,
+			watchlistSearch,
+		},
+		SLA: TurnaroundSLA{
+			Warnings:             []time.Duration{time.Hour * 24 * 5, time.Hour * 24 * 7},
+			Deadline:             time.Hour * 24 * 10,
+			ReleasePartialReport: true,
+		},
+	},
+}
+
+// Packages returns the search packages that can be requested for a background check.
+func Packages() []SearchPackage {
+	return searchPackages
+}
+
+// LookupPackage returns the search package with the given name.
+func LookupPackage(name string) (SearchPackage, error) {
+	for _, p := range searchPackages {
+		if p.Name == name {
+			return p, nil
+		}
+	}
+
+	return SearchPackage{}, fmt.Errorf("unknown package: %s", name)
+}
+
+// LookupSearch returns the definition of the search with the given name from any package.
+func LookupSearch(name string) (SearchDefinition, error) {
+	for _, p := range searchPackages {
+		for _, search := range p.Searches {
+			if search.Name == name {
+				return search, nil
+			}
+		}
+	}
+
+	return SearchDefinition{}, fmt.Errorf("unknown search: %s", name)
+}
@@ -0,0 +1,104 @@
+package workflows
+
+import (
+	"time"
+
+	"github.com/temporalio/background-checks/activities"
+	"go.temporal.io/sdk/workflow"
+)
+
+const (
+	LicenseStatusActive   = "active"
+	LicenseStatusNotFound = "not_found"
+
+	LicenseProblemNotFound   = "not_found"
+	LicenseProblemInactive   = "inactive"
+	LicenseProblemExpired    = "expired"
+	LicenseProblemSanctioned = "sanctioned"
+)
+
+type ProfessionalLicenseSearchWorkflowInput struct {
+	FullName string
+	Licenses []LicenseRecord
+}
+
+// ProfessionalLicenseResult is the licensing board's record of one of the candidate's licenses.
+type ProfessionalLicenseResult struct {
+	Type      string
+	Number    string
+	State     string
+	Status    string
+	Expires   string
+	Sanctions []string
+	// Problems lists anything about the license that the Hiring Manager should be aware of,
+	// such as it having expired or the holder having been sanctioned.
+	Problems []string
+}
+
+type ProfessionalLicenseSearchWorkflowResult struct {
+	Licenses []ProfessionalLicenseResult
+}
+
+// licenseProblems works out what, if anything, is wrong with a license as of now.
+func licenseProblems(license ProfessionalLicenseResult, now time.Time) []string {
+	var problems []string
+
+	if license.Status == LicenseStatusNotFound {
+		return []string{LicenseProblemNotFound}
+	}
+	if license.Status != LicenseStatusActive {
+		problems = append(problems, LicenseProblemInactive)
+	}
+	if expires, err := time.Parse("2006-01-02", license.Expires); err == nil && now.After(expires) {
+		problems = append(problems, LicenseProblemExpired)
+	}
+	if len(license.Sanctions) > 0 {
+		problems = append(problems, LicenseProblemSanctioned)
+	}
+
+	return problems
+}
+
+// @@@SNIPSTART background-checks-professional-license-workflow-definition
+
+// ProfessionalLicenseSearch is a Workflow Definition that looks up each of the candidate's professional licenses
+// with the licensing board, and flags any that are not active, have expired, or carry disciplinary sanctions.
+// This is executed as a Child Workflow by the main Background Check.
+func ProfessionalLicenseSearch(ctx workflow.Context, input *ProfessionalLicenseSearchWorkflowInput) (*ProfessionalLicenseSearchWorkflowResult, error) {
+	var result ProfessionalLicenseSearchWorkflowResult
+
+	ctx = withVendorOptions(ctx, VendorLicensing)
+
+	for _, license := range input.Licenses {
+		var activityResult activities.LicenseRecordSearchResult
+
+		err := executeVendorActivity[activities.LicenseRecordSearchResult](ctx, a.LicenseRecordSearch, activities.LicenseRecordSearchInput{
+			FullName: input.FullName,
+			Type:     license.Type,
+			Number:   license.Number,
+			State:    license.State,
+		}).Get(ctx, &activityResult)
+		if err != nil {
+			return &result, err
+		}
+
+		r := ProfessionalLicenseResult{
+			Type:      license.Type,
+			Number:    license.Number,
+			State:     license.State,
+			Status:    activityResult.Status,
+			Expires:   activityResult.Expires,
+			Sanctions: activityResult.Sanctions,
+		}
+		if !activityResult.Found {
+			r.Status = LicenseStatusNotFound
+		}
+		r.Problems = licenseProblems(r, workflow.Now(ctx))
+
+		result.Licenses = append(result.Licenses, r)
+	}
+
+	return &result, nil
+}
+
+// @@@SNIPEND
@@ -0,0 +1,52 @@
+package workflows_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/temporalio/background-checks/activities"
+	"github.com/temporalio/background-checks/workflows"
+	"go.temporal.io/sdk/testsuite"
+)
+
+func TestProfessionalLicenseSearchWorkflow(t *testing.T) {
+	s := testsuite.WorkflowTestSuite{}
+	env := s.NewTestWorkflowEnvironment()
+	var a *activities.Activities
+
+	records := map[string]activities.LicenseRecordSearchResult{
+		"123456": {Found: true, Status: "active", Expires: "2999-06-30"},
+		"654321": {Found: true, Status: "active", Expires: "2001-01-31"},
+		"887766": {Found: true, Status: "active", Expires: "2999-12-31", Sanctions: []string{"Public reprimand, 2019"}},
+	}
+
+	env.OnActivity(a.LicenseRecordSearch, mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, input *activities.LicenseRecordSearchInput) (*activities.LicenseRecordSearchResult, error) {
+			r := records[input.Number]
+			return &r, nil
+		},
+	)
+
+	env.ExecuteWorkflow(workflows.ProfessionalLicenseSearch, &workflows.ProfessionalLicenseSearchWorkflowInput{
+		FullName: "John Smith",
+		Licenses: []workflows.LicenseRecord{
+			{Type: "RN", Number: "123456", State: "NY"},
+			{Type: "RN", Number: "654321", State: "CA"},
+			{Type: "CPA", Number: "887766", State: "NY"},
+			{Type: "CPA", Number: "000000", State: "NY"},
+		},
+	})
+
+	var result workflows.ProfessionalLicenseSearchWorkflowResult
+	err := env.GetWorkflowResult(&result)
+	assert.NoError(t, err)
+
+	assert.Len(t, result.Licenses, 4)
+	assert.Empty(t, result.Licenses[0].Problems)
+	assert.Equal(t, []string{workflows.LicenseProblemExpired}, result.Licenses[1].Problems)
+	assert.Equal(t, []string{workflows.LicenseProblemSanctioned}, result.Licenses[2].Problems)
+	assert.Equal(t, workflows.LicenseStatusNotFound, result.Licenses[3].Status)
+	assert.Equal(t, []string{workflows.LicenseProblemNotFound}, result.Licenses[3].Problems)
+}
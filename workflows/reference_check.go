@@ -0,0 +1,235 @@
+package workflows
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"time"
+
+	"github.com/temporalio/background-checks/activities"
+	"go.temporal.io/sdk/workflow"
+)
+
+const (
+	ReferenceDetailsQuery       = "reference-details"
+	ReferenceResponseSignalName = "reference-response"
+
+	// DefaultReferenceDeadline is how long references have to respond before we report what we have.
+	DefaultReferenceDeadline = time.Hour * 24 * 7
+	// DefaultReferenceReminderInterval is how often references who haven't responded are reminded.
+	DefaultReferenceReminderInterval = time.Hour * 24 * 2
+
+	MinReferences = 2
+	MaxReferences = 3
+
+	ReferenceStatusPending    = "pending"
+	ReferenceStatusResponded  = "responded"
+	ReferenceStatusNoResponse = "no_response"
+	ReferenceStatusCancelled  = "cancelled"
+)
+
+// ValidateReferences checks the references a candidate has given us. References are optional,
+// but if the candidate gives any they must give between MinReferences and MaxReferences.
+func ValidateReferences(references []Reference) error {
+	if len(references) == 0 {
+		return nil
+	}
+	if len(references) < MinReferences || len(references) > MaxReferences {
+		return fmt.Errorf("please provide between %d and %d references", MinReferences, MaxReferences)
+	}
+
+	for _, r := range references {
+		if r.Name == "" {
+			return errors.New("each reference must have a name")
+		}
+		if _, err := mail.ParseAddress(r.Email); err != nil {
+			return fmt.Errorf("invalid email address for reference %s: %q", r.Name, r.Email)
+		}
+	}
+
+	return nil
+}
+
+type ReferenceCheckWorkflowInput struct {
+	CandidateName string
+	References    []Reference
+	// Deadline is how long references have to respond. DefaultReferenceDeadline is used if it is not set.
+	Deadline time.Duration
+	// ReminderInterval is how often references are reminded. DefaultReferenceReminderInterval is used if it is not set.
+	ReminderInterval time.Duration
+}
+
+// ReferenceDetails is what a reference is shown when they open their questionnaire link.
+type ReferenceDetails struct {
+	CandidateName string
+	ReferenceName string
+	Company       string
+}
+
+// ReferenceCheckResult is the outcome of contacting one of the candidate's references.
+type ReferenceCheckResult struct {
+	Name         string
+	Email        string
+	Company      string
+	Relationship string
+	Status       string
+	Response     *ReferenceResponse
+	RespondedAt  time.Time
+}
+
+type ReferenceCheckWorkflowResult struct {
+	References []ReferenceCheckResult
+}
+
+// emailReferenceRequest sends a reference the link to their questionnaire.
+// If reminder is true the email reminds the reference about a request they have already been sent.
+func emailReferenceRequest(ctx workflow.Context, input *ReferenceCheckWorkflowInput, reference Reference, token string, reminder bool) error {
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: time.Minute,
+	})
+
+	f := workflow.ExecuteActivity(ctx, a.SendReferenceRequestEmail, activities.SendReferenceRequestEmailInput{
+		Email:         reference.Email,
+		Name:          reference.Name,
+		CandidateName: input.CandidateName,
+		Token:         token,
+		Reminder:      reminder,
+	})
+	return f.Get(ctx, nil)
+}
+
+// waitForReferenceResponse waits for a response from any reference, or for the timeout to pass.
+// The returned bool is false if the timeout passed without a response.
+func waitForReferenceResponse(ctx workflow.Context, timeout time.Duration) (ReferenceResponseSignal, bool) {
+	var response ReferenceResponseSignal
+	var received bool
+
+	ctx, cancelTimer := workflow.WithCancel(ctx)
+	defer cancelTimer()
+
+	s := workflow.NewSelector(ctx)
+	s.AddReceive(workflow.GetSignalChannel(ctx, ReferenceResponseSignalName), func(c workflow.ReceiveChannel, more bool) {
+		c.Receive(ctx, &response)
+		received = true
+	})
+	s.AddFuture(workflow.NewTimer(ctx, timeout), func(f workflow.Future) {})
+
+	s.Select(ctx)
+
+	return response, received
+}
+
+// @@@SNIPSTART background-checks-reference-check-workflow-definition
+
+// ReferenceCheck is a Workflow Definition that asks each of the candidate's references to fill in a questionnaire.
+// Each reference is sent their own link, and reminded until they respond. Once the deadline passes the check
+// completes with whatever responses have been received, and the remaining references are reported as not responding.
+// This is executed as a Child Workflow by the main Background Check.
+func ReferenceCheck(ctx workflow.Context, input *ReferenceCheckWorkflowInput) (*ReferenceCheckWorkflowResult, error) {
+	var result ReferenceCheckWorkflowResult
+
+	logger := workflow.GetLogger(ctx)
+
+	if input.Deadline <= 0 {
+		input.Deadline = DefaultReferenceDeadline
+	}
+	if input.ReminderInterval <= 0 {
+		input.ReminderInterval = DefaultReferenceReminderInterval
+	}
+
+	tokens := make([]string, len(input.References))
+	references := make(map[string]int, len(input.References))
+	result.References = make([]ReferenceCheckResult, len(input.References))
+
+	for i, reference := range input.References {
+		tokens[i] = TokenForAssignment(ctx, fmt.Sprintf("reference-%d", i+1))
+		references[tokens[i]] = i
+		result.References[i] = ReferenceCheckResult{
+			Name:         reference.Name,
+			Email:        reference.Email,
+			Company:      reference.Company,
+			Relationship: reference.Relationship,
+			Status:       ReferenceStatusPending,
+		}
+	}
+
+	err := workflow.SetQueryHandler(ctx, ReferenceDetailsQuery, func(token string) (ReferenceDetails, error) {
+		i, ok := references[token]
+		if !ok {
+			return ReferenceDetails{}, errors.New("unknown reference")
+		}
+		if result.References[i].Status != ReferenceStatusPending {
+			return ReferenceDetails{}, errors.New("this reference request is closed")
+		}
+		return ReferenceDetails{CandidateName: input.CandidateName, ReferenceName: input.References[i].Name, Company: input.References[i].Company}, nil
+	})
+	if err != nil {
+		return &result, err
+	}
+
+	for i, reference := range input.References {
+		err := emailReferenceRequest(ctx, input, reference, tokens[i], false)
+		if err != nil {
+			return &result, err
+		}
+	}
+
+	pending := len(input.References)
+	deadline := workflow.Now(ctx).Add(input.Deadline)
+	nextReminder := workflow.Now(ctx).Add(input.ReminderInterval)
+
+	for pending > 0 && workflow.Now(ctx).Before(deadline) {
+		wait := nextReminder
+		if deadline.Before(wait) {
+			wait = deadline
+		}
+
+		response, ok := waitForReferenceResponse(ctx, wait.Sub(workflow.Now(ctx)))
+		if ctx.Err() != nil {
+			// Closing the outstanding requests revokes the references' tokens.
+			for i := range result.References {
+				if result.References[i].Status == ReferenceStatusPending {
+					result.References[i].Status = ReferenceStatusCancelled
+				}
+			}
+			return &result, ctx.Err()
+		}
+		if ok {
+			i, known := references[response.Token]
+			if !known || result.References[i].Status != ReferenceStatusPending {
+				logger.Warn("Ignoring unexpected reference response")
+				continue
+			}
+
+			r := response.Response
+			result.References[i].Status = ReferenceStatusResponded
+			result.References[i].Response = &r
+			result.References[i].RespondedAt = workflow.Now(ctx)
+			pending--
+			continue
+		}
+
+		if !workflow.Now(ctx).Before(nextReminder) && workflow.Now(ctx).Before(deadline) {
+			for i, reference := range input.References {
+				if result.References[i].Status != ReferenceStatusPending {
+					continue
+				}
+				err := emailReferenceRequest(ctx, input, reference, tokens[i], true)
+				if err != nil {
+					logger.Error("Failed to send reference reminder", "reference", reference.Email, "error", err)
+				}
+			}
+			nextReminder = workflow.Now(ctx).Add(input.ReminderInterval)
+		}
+	}
+
+	for i := range result.References {
+		if result.References[i].Status == ReferenceStatusPending {
+			result.References[i].Status = ReferenceStatusNoResponse
+		}
+	}
+
+	return &result, nil
+}
+
+// @@@SNIPEND
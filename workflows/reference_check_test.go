@@ -0,0 +1,132 @@
+package workflows_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/temporalio/background-checks/activities"
+	"github.com/temporalio/background-checks/workflows"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+)
+
+func TestReferenceCheckWorkflow(t *testing.T) {
+	s := testsuite.WorkflowTestSuite{}
+	env := s.NewTestWorkflowEnvironment()
+	var a *activities.Activities
+
+	tokens := map[string]string{}
+	reminders := map[string]int{}
+	env.OnActivity(a.SendReferenceRequestEmail, mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, input *activities.SendReferenceRequestEmailInput) (*activities.SendReferenceRequestEmailResult, error) {
+			tokens[input.Email] = input.Token
+			if input.Reminder {
+				reminders[input.Email]++
+			}
+			return &activities.SendReferenceRequestEmailResult{}, nil
+		},
+	)
+
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(
+			workflows.ReferenceResponseSignalName,
+			workflows.ReferenceResponseSignal{Token: tokens["ann@example.com"], Response: workflows.ReferenceResponse{Rating: 5, WouldRehire: true}},
+		)
+	}, time.Hour)
+	env.RegisterDelayedCallback(func() {
+		// A response with an unknown token is ignored.
+		env.SignalWorkflow(
+			workflows.ReferenceResponseSignalName,
+			workflows.ReferenceResponseSignal{Token: "invalid", Response: workflows.ReferenceResponse{Rating: 1}},
+		)
+		// A second response from the same reference is ignored.
+		env.SignalWorkflow(
+			workflows.ReferenceResponseSignalName,
+			workflows.ReferenceResponseSignal{Token: tokens["ann@example.com"], Response: workflows.ReferenceResponse{Rating: 1}},
+		)
+	}, time.Hour*2)
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(
+			workflows.ReferenceResponseSignalName,
+			workflows.ReferenceResponseSignal{Token: tokens["bob@example.com"], Response: workflows.ReferenceResponse{Rating: 4}},
+		)
+	}, time.Hour*24*3)
+
+	env.ExecuteWorkflow(workflows.ReferenceCheck, &workflows.ReferenceCheckWorkflowInput{
+		CandidateName: "John Smith",
+		References: []workflows.Reference{
+			{Name: "Ann", Email: "ann@example.com", Company: "Acme"},
+			{Name: "Bob", Email: "bob@example.com", Company: "Acme"},
+			{Name: "Cat", Email: "cat@example.com", Company: "Initech"},
+		},
+	})
+
+	var result workflows.ReferenceCheckWorkflowResult
+	err := env.GetWorkflowResult(&result)
+	assert.NoError(t, err)
+
+	assert.Len(t, result.References, 3)
+
+	assert.Equal(t, workflows.ReferenceStatusResponded, result.References[0].Status)
+	assert.Equal(t, 5, result.References[0].Response.Rating)
+	assert.Equal(t, workflows.ReferenceStatusResponded, result.References[1].Status)
+	assert.Equal(t, 4, result.References[1].Response.Rating)
+	assert.Equal(t, workflows.ReferenceStatusNoResponse, result.References[2].Status)
+	assert.Nil(t, result.References[2].Response)
+
+	// Reminders go out every two days until the seven day deadline, but only to references who haven't responded.
+	assert.Equal(t, 0, reminders["ann@example.com"])
+	assert.Equal(t, 1, reminders["bob@example.com"])
+	assert.Equal(t, 3, reminders["cat@example.com"])
+}
+
+func TestReferenceCheckWorkflowCancelled(t *testing.T) {
+	s := testsuite.WorkflowTestSuite{}
+	env := s.NewTestWorkflowEnvironment()
+	var a *activities.Activities
+
+	tokens := map[string]string{}
+	env.OnActivity(a.SendReferenceRequestEmail, mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, input *activities.SendReferenceRequestEmailInput) (*activities.SendReferenceRequestEmailResult, error) {
+			tokens[input.Email] = input.Token
+			return &activities.SendReferenceRequestEmailResult{}, nil
+		},
+	)
+
+	env.RegisterDelayedCallback(env.CancelWorkflow, time.Hour)
+
+	env.ExecuteWorkflow(workflows.ReferenceCheck, &workflows.ReferenceCheckWorkflowInput{
+		CandidateName: "John Smith",
+		References: []workflows.Reference{
+			{Name: "Ann", Email: "ann@example.com", Company: "Acme"},
+			{Name: "Bob", Email: "bob@example.com", Company: "Acme"},
+		},
+	})
+
+	assert.True(t, temporal.IsCanceledError(env.GetWorkflowError()))
+
+	// The references' links stop working once the check is cancelled.
+	_, err := env.QueryWorkflow(workflows.ReferenceDetailsQuery, tokens["ann@example.com"])
+	assert.Error(t, err)
+}
+
+func TestValidateReferences(t *testing.T) {
+	assert.NoError(t, workflows.ValidateReferences(nil))
+
+	assert.NoError(t, workflows.ValidateReferences([]workflows.Reference{
+		{Name: "Ann", Email: "ann@example.com"},
+		{Name: "Bob", Email: "bob@example.com"},
+	}))
+
+	assert.Error(t, workflows.ValidateReferences([]workflows.Reference{
+		{Name: "Ann", Email: "ann@example.com"},
+	}))
+
+	assert.Error(t, workflows.ValidateReferences([]workflows.Reference{
+		{Name: "Ann", Email: "ann@example.com"},
+		{Name: "Bob", Email: "not an email"},
+	}))
+}
@@ -0,0 +1,539 @@
+package workflows
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/temporalio/background-checks/activities"
+	"go.temporal.io/sdk/log"
+	"go.temporal.io/sdk/workflow"
+)
+
+const (
+	ResearcherPoolQuery                   = "researcher-pool"
+	ResearcherAssignmentRequestSignalName = "researcher-assignment-request"
+	ResearcherReleaseSignalName           = "researcher-release"
+	ResearcherUpsertSignalName            = "researcher-upsert"
+	ResearcherRemoveSignalName            = "researcher-remove"
+	// ResearcherAssignmentSignalName is the signal the pool sends back to a workflow that requested a researcher.
+	ResearcherAssignmentSignalName = "researcher-assignment"
+
+	DefaultResearcherCapacity = 5
+	// researcherPoolSignalLimit is the number of signals the pool handles before continuing as new.
+	researcherPoolSignalLimit = 1000
+	// researcherPoolSweepInterval is how often the pool checks for assignments whose workflow has closed without
+	// releasing them, for example because it was terminated.
+	researcherPoolSweepInterval = time.Hour
+
+	ResearcherSkillEmployment      = "employment"
+	ResearcherSkillEducation       = "education"
+	ResearcherSkillCriminalRecords = "criminal"
+	ResearcherSkillIdentity        = "identity"
+	ResearcherSkillDrugScreen      = "drug_screen"
+)
+
+// DefaultResearchers are used to seed the pool when it is first started.
+var DefaultResearchers = []Researcher{
+	{Email: "researcher1@example.com", Skills: []string{ResearcherSkillEmployment, ResearcherSkillEducation, ResearcherSkillCriminalRecords, ResearcherSkillIdentity, ResearcherSkillDrugScreen}, Capacity: DefaultResearcherCapacity, Available: true},
+	{Email: "researcher2@example.com", Skills: []string{ResearcherSkillEmployment, ResearcherSkillEducation, ResearcherSkillCriminalRecords, ResearcherSkillIdentity, ResearcherSkillDrugScreen}, Capacity: DefaultResearcherCapacity, Available: true},
+	{Email: "researcher3@example.com", Skills: []string{ResearcherSkillEmployment, ResearcherSkillEducation, ResearcherSkillCriminalRecords, ResearcherSkillIdentity, ResearcherSkillDrugScreen}, Capacity: DefaultResearcherCapacity, Available: true},
+}
+
+// Researcher is a member of the researcher pool.
+type Researcher struct {
+	Email string
+	// Skills are the kinds of research the researcher can be assigned.
+	Skills []string
+	// Jurisdictions are the states the researcher covers. A researcher with no jurisdictions covers them all.
+	Jurisdictions []string
+	// Capacity is the number of assignments the researcher can have open at once.
+	Capacity  int
+	Available bool
+	// OpenAssignments holds the IDs of the researcher's current assignments.
+	OpenAssignments []string
+}
+
+func (r *Researcher) hasSkill(skill string) bool {
+	for _, s := range r.Skills {
+		if s == skill {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Researcher) covers(jurisdiction string) bool {
+	if jurisdiction == "" || len(r.Jurisdictions) == 0 {
+		return true
+	}
+	for _, j := range r.Jurisdictions {
+		if j == jurisdiction {
+			return true
+		}
+	}
+	return false
+}
+
+// ResearcherAssignmentRequest asks the pool for a researcher. The pool replies to the requesting workflow
+// with a ResearcherAssignmentSignal once a researcher with capacity is available.
+type ResearcherAssignmentRequest struct {
+	AssignmentID string
+	WorkflowID   string
+	RunID        string
+	Skill        string
+	Jurisdiction string
+	// Exclude lists researchers that should not be chosen if anyone else is able to take the assignment.
+	Exclude []string
+}
+
+type ResearcherAssignmentSignal struct {
+	AssignmentID string
+	Researcher   string
+}
+
+type ResearcherReleaseSignal struct {
+	AssignmentID string
+}
+
+type ResearcherRemoveSignal struct {
+	Email string
+}
+
+type ResearcherPoolWorkflowInput struct {
+	Researchers []Researcher
+	Pending     []ResearcherAssignmentRequest
+	Owners      map[string]activities.WorkflowExecution
+}
+
+type ResearcherPoolState struct {
+	Researchers []Researcher
+	Pending     []ResearcherAssignmentRequest
+	// Owners maps each open assignment to the workflow execution it was made for.
+	Owners map[string]activities.WorkflowExecution
+}
+
+type researcherPool struct {
+	ResearcherPoolState
+	logger log.Logger
+}
+
+// choose returns the index of the least loaded researcher able to take the request, or -1 if there is nobody.
+func (p *researcherPool) choose(request ResearcherAssignmentRequest) int {
+	excluded := make(map[string]bool, len(request.Exclude))
+	for _, e := range request.Exclude {
+		excluded[e] = true
+	}
+
+	best, fallback := -1, -1
+	for i, r := range p.Researchers {
+		if !r.Available || len(r.OpenAssignments) >= r.Capacity || !r.hasSkill(request.Skill) || !r.covers(request.Jurisdiction) {
+			continue
+		}
+		if excluded[r.Email] {
+			if fallback == -1 || len(r.OpenAssignments) < len(p.Researchers[fallback].OpenAssignments) {
+				fallback = i
+			}
+			continue
+		}
+		if best == -1 || len(r.OpenAssignments) < len(p.Researchers[best].OpenAssignments) {
+			best = i
+		}
+	}
+
+	if best == -1 {
+		return fallback
+	}
+	return best
+}
+
+// assignPending hands out researchers to any waiting requests, in the order they were made.
+func (p *researcherPool) assignPending(ctx workflow.Context) {
+	var waiting []ResearcherAssignmentRequest
+
+	for _, request := range p.Pending {
+		i := p.choose(request)
+		if i == -1 {
+			waiting = append(waiting, request)
+			continue
+		}
+
+		p.Researchers[i].OpenAssignments = append(p.Researchers[i].OpenAssignments, request.AssignmentID)
+		p.Owners[request.AssignmentID] = activities.WorkflowExecution{WorkflowID: request.WorkflowID, RunID: request.RunID}
+
+		err := workflow.SignalExternalWorkflow(ctx, request.WorkflowID, "", ResearcherAssignmentSignalName, ResearcherAssignmentSignal{
+			AssignmentID: request.AssignmentID,
+			Researcher:   p.Researchers[i].Email,
+		}).Get(ctx, nil)
+		if err != nil {
+			// The requesting workflow has gone away, so free up the researcher again.
+			p.logger.Warn("Unable to deliver researcher assignment", "workflowID", request.WorkflowID, "error", err)
+			p.release(request.AssignmentID)
+		}
+	}
+
+	p.Pending = waiting
+}
+
+// release frees the researcher holding an assignment, or drops the request if it is still waiting.
+func (p *researcherPool) release(assignmentID string) {
+	delete(p.Owners, assignmentID)
+
+	for i, r := range p.Researchers {
+		for j, id := range r.OpenAssignments {
+			if id == assignmentID {
+				p.Researchers[i].OpenAssignments = append(r.OpenAssignments[:j:j], r.OpenAssignments[j+1:]...)
+				return
+			}
+		}
+	}
+
+	for i, request := range p.Pending {
+		if request.AssignmentID == assignmentID {
+			p.Pending = append(p.Pending[:i:i], p.Pending[i+1:]...)
+			return
+		}
+	}
+}
+
+// sweep releases any assignments, and drops any waiting requests, made for workflows that have since closed.
+// Workflows release their own assignments as they finish, but a workflow that is terminated never gets the chance.
+func (p *researcherPool) sweep(ctx workflow.Context) {
+	var executions []activities.WorkflowExecution
+	seen := make(map[activities.WorkflowExecution]bool)
+	add := func(execution activities.WorkflowExecution) {
+		if !seen[execution] {
+			seen[execution] = true
+			executions = append(executions, execution)
+		}
+	}
+
+	for _, r := range p.Researchers {
+		for _, id := range r.OpenAssignments {
+			if owner, ok := p.Owners[id]; ok {
+				add(owner)
+			}
+		}
+	}
+	for _, request := range p.Pending {
+		add(activities.WorkflowExecution{WorkflowID: request.WorkflowID, RunID: request.RunID})
+	}
+
+	if len(executions) == 0 {
+		return
+	}
+
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: time.Minute,
+	})
+
+	var result activities.ClosedWorkflowsResult
+	err := workflow.ExecuteActivity(ctx, a.ClosedWorkflows, activities.ClosedWorkflowsInput{Executions: executions}).Get(ctx, &result)
+	if err != nil {
+		p.logger.Error("Unable to check for closed workflows", "error", err)
+		return
+	}
+
+	closed := make(map[activities.WorkflowExecution]bool, len(result.Executions))
+	for _, execution := range result.Executions {
+		closed[execution] = true
+	}
+
+	var abandoned []string
+	for _, r := range p.Researchers {
+		for _, id := range r.OpenAssignments {
+			if owner, ok := p.Owners[id]; ok && closed[owner] {
+				abandoned = append(abandoned, id)
+			}
+		}
+	}
+	for _, request := range p.Pending {
+		if closed[activities.WorkflowExecution{WorkflowID: request.WorkflowID, RunID: request.RunID}] {
+			abandoned = append(abandoned, request.AssignmentID)
+		}
+	}
+
+	for _, id := range abandoned {
+		p.logger.Warn("Releasing assignment for closed workflow", "assignmentID", id)
+		p.release(id)
+	}
+}
+
+// upsert adds a researcher to the pool or updates their details. Open assignments are kept.
+func (p *researcherPool) upsert(researcher Researcher) {
+	if researcher.Capacity <= 0 {
+		researcher.Capacity = DefaultResearcherCapacity
+	}
+
+	for i, r := range p.Researchers {
+		if r.Email == researcher.Email {
+			researcher.OpenAssignments = r.OpenAssignments
+			p.Researchers[i] = researcher
+			return
+		}
+	}
+
+	researcher.OpenAssignments = nil
+	p.Researchers = append(p.Researchers, researcher)
+}
+
+// remove takes a researcher out of the pool. Their open assignments are left with them to complete.
+func (p *researcherPool) remove(email string) {
+	for i, r := range p.Researchers {
+		if r.Email == email {
+			p.Researchers = append(p.Researchers[:i:i], p.Researchers[i+1:]...)
+			return
+		}
+	}
+}
+
+// @@@SNIPSTART background-checks-researcher-pool-workflow-definition
+
+// ResearcherPool is a long running entity Workflow Definition that keeps track of the researchers available to
+// perform manual verifications and their open assignments. Workflows request a researcher by signal and are
+// sent an assignment by signal in return, choosing the least loaded researcher with the right skills.
+// Requests wait in the pool until a researcher has capacity.
+// Assignments made for workflows that have closed without releasing them are released periodically.
+// The workflow continues as new periodically to keep its history bounded.
+func ResearcherPool(ctx workflow.Context, input *ResearcherPoolWorkflowInput) error {
+	p := researcherPool{
+		ResearcherPoolState: ResearcherPoolState{
+			Researchers: input.Researchers,
+			Pending:     input.Pending,
+			Owners:      input.Owners,
+		},
+		logger: workflow.GetLogger(ctx),
+	}
+	if p.Owners == nil {
+		p.Owners = make(map[string]activities.WorkflowExecution)
+	}
+
+	err := workflow.SetQueryHandler(ctx, ResearcherPoolQuery, func() (ResearcherPoolState, error) {
+		return p.ResearcherPoolState, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	requestCh := workflow.GetSignalChannel(ctx, ResearcherAssignmentRequestSignalName)
+	releaseCh := workflow.GetSignalChannel(ctx, ResearcherReleaseSignalName)
+	upsertCh := workflow.GetSignalChannel(ctx, ResearcherUpsertSignalName)
+	removeCh := workflow.GetSignalChannel(ctx, ResearcherRemoveSignalName)
+
+	handleRequest := func(c workflow.ReceiveChannel, more bool) {
+		var request ResearcherAssignmentRequest
+		c.Receive(ctx, &request)
+		p.Pending = append(p.Pending, request)
+	}
+	handleRelease := func(c workflow.ReceiveChannel, more bool) {
+		var release ResearcherReleaseSignal
+		c.Receive(ctx, &release)
+		p.release(release.AssignmentID)
+	}
+	handleUpsert := func(c workflow.ReceiveChannel, more bool) {
+		var researcher Researcher
+		c.Receive(ctx, &researcher)
+		p.upsert(researcher)
+	}
+	handleRemove := func(c workflow.ReceiveChannel, more bool) {
+		var remove ResearcherRemoveSignal
+		c.Receive(ctx, &remove)
+		p.remove(remove.Email)
+	}
+
+	s := workflow.NewSelector(ctx)
+	s.AddReceive(requestCh, handleRequest)
+	s.AddReceive(releaseCh, handleRelease)
+	s.AddReceive(upsertCh, handleUpsert)
+	s.AddReceive(removeCh, handleRemove)
+
+	var sweep func()
+	sweep = func() {
+		s.AddFuture(workflow.NewTimer(ctx, researcherPoolSweepInterval), func(f workflow.Future) {
+			p.sweep(ctx)
+			sweep()
+		})
+	}
+	sweep()
+
+	// Requests carried over from a previous run may be assignable straight away.
+	p.assignPending(ctx)
+
+	for i := 0; i < researcherPoolSignalLimit; i++ {
+		s.Select(ctx)
+		p.assignPending(ctx)
+	}
+
+	// Handle any signals that arrived before we continue as new so they aren't lost.
+	for s.HasPending() {
+		s.Select(ctx)
+	}
+	p.assignPending(ctx)
+
+	return workflow.NewContinueAsNewError(ctx, ResearcherPool, &ResearcherPoolWorkflowInput{
+		Researchers: p.Researchers,
+		Pending:     p.Pending,
+		Owners:      p.Owners,
+	})
+}
+
+// @@@SNIPEND
+
+// requestResearcher asks the researcher pool for a researcher and waits until one is assigned or the deadline passes.
+// The returned researcher is empty if nobody could be assigned in time.
+func requestResearcher(ctx workflow.Context, request ResearcherAssignmentRequest, deadline time.Time) (string, error) {
+	info := workflow.GetInfo(ctx)
+	request.WorkflowID = info.WorkflowExecution.ID
+	request.RunID = info.WorkflowExecution.RunID
+
+	err := workflow.SignalExternalWorkflow(ctx, ResearcherPoolWorkflowID(), "", ResearcherAssignmentRequestSignalName, request).Get(ctx, nil)
+	if err != nil {
+		return "", fmt.Errorf("unable to request researcher: %w", err)
+	}
+
+	ctx, cancelTimer := workflow.WithCancel(ctx)
+	defer cancelTimer()
+
+	var researcher string
+	var expired bool
+
+	s := workflow.NewSelector(ctx)
+	s.AddReceive(workflow.GetSignalChannel(ctx, ResearcherAssignmentSignalName), func(c workflow.ReceiveChannel, more bool) {
+		var assignment ResearcherAssignmentSignal
+		c.Receive(ctx, &assignment)
+		if assignment.AssignmentID != request.AssignmentID {
+			// A reply for an assignment we have already given up on.
+			releaseResearcher(ctx, assignment.AssignmentID)
+			return
+		}
+		researcher = assignment.Researcher
+	})
+	s.AddFuture(workflow.NewTimer(ctx, deadline.Sub(workflow.Now(ctx))), func(f workflow.Future) {
+		expired = true
+	})
+
+	for researcher == "" && !expired {
+		s.Select(ctx)
+	}
+
+	if researcher == "" {
+		// Withdraw the request so that the pool doesn't assign someone to work we no longer need.
+		releaseResearcher(ctx, request.AssignmentID)
+	}
+
+	return researcher, nil
+}
+
+// releaseResearcher tells the researcher pool that an assignment is finished, freeing up the researcher's capacity.
+func releaseResearcher(ctx workflow.Context, assignmentID string) {
+	// Use a disconnected context so the researcher is released even if we are being cancelled.
+	ctx, _ = workflow.NewDisconnectedContext(ctx)
+
+	err := workflow.SignalExternalWorkflow(ctx, ResearcherPoolWorkflowID(), "", ResearcherReleaseSignalName, ResearcherReleaseSignal{
+		AssignmentID: assignmentID,
+	}).Get(ctx, nil)
+	if err != nil {
+		workflow.GetLogger(ctx).Error("Unable to release researcher", "assignmentID", assignmentID, "error", err)
+	}
+}
+
+// researchAssignment is the researcher currently responsible for a piece of research.
+// Each assignment has its own token, so a researcher's link stops working once the work is reassigned or cancelled.
+type researchAssignment struct {
+	Researcher string
+	Token      string
+}
+
+// assignResearch hands a piece of work to researchers from the pool in turn. Each researcher is given a new
+// assignment, with its own token, and has until the SLA passes to finish. If they don't, the work is reassigned
+// to somebody else and their token is revoked. attempt is called for each assignment, with current set to that
+// assignment, and reports whether the work was finished. If the work is cancelled the current assignment is cleared,
+// revoking its token.
+// The returned bool is false if the work wasn't finished before the deadline.
+func assignResearch(ctx workflow.Context, request ResearcherAssignmentRequest, sla time.Duration, deadline time.Time, current *researchAssignment, attempt func(until time.Time) (bool, error)) (bool, error) {
+	logger := workflow.GetLogger(ctx)
+
+	for i := 1; ; i++ {
+		// The token also identifies the assignment to the researcher pool.
+		token := TokenForAssignment(ctx, fmt.Sprintf("assignment-%d", i))
+		request.AssignmentID = token
+
+		researcher, err := requestResearcher(ctx, request, deadline)
+		if err != nil {
+			return false, err
+		}
+		if ctx.Err() != nil {
+			return false, ctx.Err()
+		}
+		if researcher == "" {
+			return false, nil
+		}
+
+		// Replacing the assignment revokes the previous researcher's token.
+		*current = researchAssignment{
+			Researcher: researcher,
+			Token:      token,
+		}
+
+		until := workflow.Now(ctx).Add(sla)
+		if until.After(deadline) {
+			until = deadline
+		}
+
+		done, err := attempt(until)
+		releaseResearcher(ctx, token)
+		if !done && ctx.Err() != nil {
+			*current = researchAssignment{}
+			return false, ctx.Err()
+		}
+		if err != nil || done {
+			return done, err
+		}
+
+		if !workflow.Now(ctx).Before(deadline) {
+			return false, nil
+		}
+
+		logger.Info("Reassigning research", "researcher", researcher)
+		request.Exclude = append(request.Exclude, researcher)
+	}
+}
+
+// waitForResearchSubmission waits for a researcher's submission on the named signal channel, calling remind
+// periodically, until the submission arrives or the assignment ends. receive decodes each signal and reports whether
+// it is an acceptable submission, so that submissions made with a revoked token can be ignored.
+// The returned bool is false if the assignment ended without a submission.
+func waitForResearchSubmission(ctx workflow.Context, signalName string, until time.Time, reminderInterval time.Duration, remind func(ctx workflow.Context) error, receive func(c workflow.ReceiveChannel) bool) bool {
+	var submitted, ended bool
+
+	logger := workflow.GetLogger(ctx)
+
+	ctx, cancelTimers := workflow.WithCancel(ctx)
+	defer cancelTimers()
+
+	s := workflow.NewSelector(ctx)
+
+	s.AddReceive(workflow.GetSignalChannel(ctx, signalName), func(c workflow.ReceiveChannel, more bool) {
+		submitted = receive(c)
+	})
+	s.AddFuture(workflow.NewTimer(ctx, until.Sub(workflow.Now(ctx))), func(f workflow.Future) {
+		ended = true
+	})
+
+	var reminder func()
+	reminder = func() {
+		s.AddFuture(workflow.NewTimer(ctx, reminderInterval), func(f workflow.Future) {
+			err := remind(ctx)
+			if err != nil {
+				logger.Error("Failed to send reminder", "error", err)
+			}
+			reminder()
+		})
+	}
+	reminder()
+
+	for !submitted && !ended {
+		s.Select(ctx)
+	}
+
+	return submitted
+}
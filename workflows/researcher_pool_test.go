@@ -0,0 +1,124 @@
+package workflows_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/temporalio/background-checks/activities"
+	"github.com/temporalio/background-checks/workflows"
+	"go.temporal.io/sdk/testsuite"
+)
+
+func TestResearcherPoolAssignsLeastLoadedResearcher(t *testing.T) {
+	s := testsuite.WorkflowTestSuite{}
+	env := s.NewTestWorkflowEnvironment()
+
+	var assignments []workflows.ResearcherAssignmentSignal
+	env.OnSignalExternalWorkflow(mock.Anything, mock.Anything, "", workflows.ResearcherAssignmentSignalName, mock.Anything).Return(
+		func(namespace, workflowID, runID, signalName string, arg interface{}) error {
+			assignments = append(assignments, arg.(workflows.ResearcherAssignmentSignal))
+			return nil
+		},
+	)
+
+	request := func(id string, skill string, jurisdiction string) func() {
+		return func() {
+			env.SignalWorkflow(
+				workflows.ResearcherAssignmentRequestSignalName,
+				workflows.ResearcherAssignmentRequest{AssignmentID: id, WorkflowID: "requester", Skill: skill, Jurisdiction: jurisdiction},
+			)
+		}
+	}
+
+	env.RegisterDelayedCallback(request("1", workflows.ResearcherSkillEmployment, ""), time.Minute)
+	env.RegisterDelayedCallback(request("2", workflows.ResearcherSkillEmployment, ""), time.Minute*2)
+	env.RegisterDelayedCallback(request("3", workflows.ResearcherSkillEmployment, "CA"), time.Minute*3)
+	// Nobody has capacity for this one until the first assignment is released.
+	env.RegisterDelayedCallback(request("4", workflows.ResearcherSkillEmployment, "CA"), time.Minute*4)
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(workflows.ResearcherReleaseSignalName, workflows.ResearcherReleaseSignal{AssignmentID: "1"})
+	}, time.Minute*5)
+
+	env.RegisterDelayedCallback(func() {
+		v, err := env.QueryWorkflow(workflows.ResearcherPoolQuery)
+		assert.NoError(t, err)
+
+		var state workflows.ResearcherPoolState
+		assert.NoError(t, v.Get(&state))
+		assert.Empty(t, state.Pending)
+		assert.Equal(t, []string{"3", "4"}, state.Researchers[0].OpenAssignments)
+		assert.Equal(t, []string{"2"}, state.Researchers[1].OpenAssignments)
+
+		env.CancelWorkflow()
+	}, time.Minute*6)
+
+	env.ExecuteWorkflow(workflows.ResearcherPool, &workflows.ResearcherPoolWorkflowInput{
+		Researchers: []workflows.Researcher{
+			{Email: "ca@example.com", Skills: []string{workflows.ResearcherSkillEmployment}, Jurisdictions: []string{"CA"}, Capacity: 2, Available: true},
+			{Email: "ny@example.com", Skills: []string{workflows.ResearcherSkillEmployment}, Jurisdictions: []string{"NY"}, Capacity: 1, Available: true},
+		},
+	})
+
+	assert.Equal(t, []workflows.ResearcherAssignmentSignal{
+		{AssignmentID: "1", Researcher: "ca@example.com"},
+		{AssignmentID: "2", Researcher: "ny@example.com"},
+		{AssignmentID: "3", Researcher: "ca@example.com"},
+		{AssignmentID: "4", Researcher: "ca@example.com"},
+	}, assignments)
+}
+
+func TestResearcherPoolReleasesAssignmentsOfClosedWorkflows(t *testing.T) {
+	s := testsuite.WorkflowTestSuite{}
+	env := s.NewTestWorkflowEnvironment()
+
+	var a *activities.Activities
+
+	env.OnSignalExternalWorkflow(mock.Anything, mock.Anything, "", workflows.ResearcherAssignmentSignalName, mock.Anything).Return(nil)
+	// The first requester was terminated, so it never released its researcher.
+	env.OnActivity(a.ClosedWorkflows, mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, input *activities.ClosedWorkflowsInput) (*activities.ClosedWorkflowsResult, error) {
+			var result activities.ClosedWorkflowsResult
+			for _, execution := range input.Executions {
+				if execution.WorkflowID == "terminated" {
+					result.Executions = append(result.Executions, execution)
+				}
+			}
+			return &result, nil
+		},
+	)
+
+	request := func(id string, workflowID string) func() {
+		return func() {
+			env.SignalWorkflow(
+				workflows.ResearcherAssignmentRequestSignalName,
+				workflows.ResearcherAssignmentRequest{AssignmentID: id, WorkflowID: workflowID, RunID: "run", Skill: workflows.ResearcherSkillEmployment},
+			)
+		}
+	}
+
+	env.RegisterDelayedCallback(request("1", "terminated"), time.Minute)
+	env.RegisterDelayedCallback(request("2", "running"), time.Minute*2)
+
+	env.RegisterDelayedCallback(func() {
+		v, err := env.QueryWorkflow(workflows.ResearcherPoolQuery)
+		assert.NoError(t, err)
+
+		var state workflows.ResearcherPoolState
+		assert.NoError(t, v.Get(&state))
+		assert.Equal(t, []string{"2"}, state.Researchers[0].OpenAssignments)
+		assert.Equal(t, map[string]activities.WorkflowExecution{
+			"2": {WorkflowID: "running", RunID: "run"},
+		}, state.Owners)
+
+		env.CancelWorkflow()
+	}, time.Hour*2)
+
+	env.ExecuteWorkflow(workflows.ResearcherPool, &workflows.ResearcherPoolWorkflowInput{
+		Researchers: []workflows.Researcher{
+			{Email: "researcher@example.com", Skills: []string{workflows.ResearcherSkillEmployment}, Capacity: 2, Available: true},
+		},
+	})
+}
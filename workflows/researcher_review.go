@@ -0,0 +1,155 @@
+package workflows
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/temporalio/background-checks/activities"
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+const (
+	ResearcherReviewDetailsQuery         = "researcher-review-details"
+	ResearcherReviewSubmissionSignalName = "researcher-review-submission"
+
+	ResearcherReviewTimedOutError = "ResearcherReviewTimedOut"
+)
+
+type ResearcherReviewWorkflowInput struct {
+	// Skill and Jurisdiction are used to choose a suitable researcher from the pool.
+	Skill        string
+	Jurisdiction string
+	// Subject is a short description of the review, used in the email to the researcher.
+	Subject string
+	// Question is what the researcher is asked to confirm.
+	Question string
+	// Details are shown to the researcher to help them with their research.
+	Details map[string]string
+	// AssignmentSLA is how long each researcher has before the review is reassigned.
+	// DefaultResearchAssignmentSLA is used if it is not set.
+	AssignmentSLA time.Duration
+	// ReminderInterval is how often the assigned researcher is reminded.
+	// DefaultResearchReminderInterval is used if it is not set.
+	ReminderInterval time.Duration
+}
+
+// ResearcherReviewDetails is what the researcher is shown when they open their review link.
+type ResearcherReviewDetails struct {
+	Subject  string
+	Question string
+	Details  map[string]string
+}
+
+type ResearcherReviewWorkflowResult struct {
+	Confirmed  bool
+	Notes      string
+	Researcher string
+}
+
+// emailResearcherReviewRequest sends the assigned researcher a link to the review.
+// If reminder is true the email reminds the researcher about a request they have already been sent.
+func emailResearcherReviewRequest(ctx workflow.Context, input *ResearcherReviewWorkflowInput, assignment *researchAssignment, reminder bool) error {
+	ctx = withVendorOptions(ctx, VendorResearchers)
+
+	f := workflow.ExecuteActivity(ctx, a.SendResearcherReviewRequestEmail, activities.SendResearcherReviewRequestEmailInput{
+		Email:    assignment.Researcher,
+		Token:    assignment.Token,
+		Subject:  input.Subject,
+		Reminder: reminder,
+	})
+	return f.Get(ctx, nil)
+}
+
+// @@@SNIPSTART background-checks-researcher-review-workflow-definition
+
+// ResearcherReview is a Workflow Definition that asks a researcher from the ResearcherPool to manually confirm
+// something a search could not settle automatically, such as a degree the education vendor has no record of.
+// Like EmploymentVerification, the review is reassigned if the researcher doesn't respond within the SLA,
+// and the workflow fails if nobody responds before the ResearchDeadline.
+// This is executed as a Child Workflow by searches that need a researcher's help.
+func ResearcherReview(ctx workflow.Context, input *ResearcherReviewWorkflowInput) (*ResearcherReviewWorkflowResult, error) {
+	var result ResearcherReviewWorkflowResult
+	var assignment researchAssignment
+
+	logger := workflow.GetLogger(ctx)
+
+	if input.AssignmentSLA <= 0 {
+		input.AssignmentSLA = DefaultResearchAssignmentSLA
+	}
+	if input.ReminderInterval <= 0 {
+		input.ReminderInterval = DefaultResearchReminderInterval
+	}
+
+	err := workflow.SetQueryHandler(ctx, ResearcherReviewDetailsQuery, func(token string) (ResearcherReviewDetails, error) {
+		if token != assignment.Token {
+			return ResearcherReviewDetails{}, fmt.Errorf("this review has been reassigned or cancelled")
+		}
+		return ResearcherReviewDetails{Subject: input.Subject, Question: input.Question, Details: input.Details}, nil
+	})
+	if err != nil {
+		return &result, err
+	}
+
+	deadline := workflow.Now(ctx).Add(ResearchDeadline)
+	request := ResearcherAssignmentRequest{Skill: input.Skill, Jurisdiction: input.Jurisdiction}
+
+	done, err := assignResearch(ctx, request, input.AssignmentSLA, deadline, &assignment, func(until time.Time) (bool, error) {
+		err := emailResearcherReviewRequest(ctx, input, &assignment, false)
+		if err != nil {
+			return false, err
+		}
+
+		submitted := waitForResearchSubmission(
+			ctx,
+			ResearcherReviewSubmissionSignalName,
+			until,
+			input.ReminderInterval,
+			func(ctx workflow.Context) error {
+				return emailResearcherReviewRequest(ctx, input, &assignment, true)
+			},
+			func(c workflow.ReceiveChannel) bool {
+				var submission ResearcherReviewSubmissionSignal
+				c.Receive(ctx, &submission)
+
+				if submission.Token != assignment.Token {
+					logger.Warn("Ignoring submission for a revoked assignment")
+					return false
+				}
+
+				result = ResearcherReviewWorkflowResult{
+					Confirmed:  submission.Confirmed,
+					Notes:      submission.Notes,
+					Researcher: assignment.Researcher,
+				}
+				return true
+			},
+		)
+
+		return submitted, nil
+	})
+	if err != nil {
+		return &result, err
+	}
+	if !done {
+		return &result, temporal.NewApplicationError("researcher review timed out", ResearcherReviewTimedOutError)
+	}
+
+	return &result, nil
+}
+
+// @@@SNIPEND
+
+// startResearcherReview starts a ResearcherReview as a child of the current workflow.
+// key must be unique among the reviews started by the current workflow.
+func startResearcherReview(ctx workflow.Context, key string, input ResearcherReviewWorkflowInput) workflow.ChildWorkflowFuture {
+	ctx = workflow.WithChildOptions(ctx, workflow.ChildWorkflowOptions{
+		WorkflowID:          ResearcherReviewWorkflowID(workflow.GetInfo(ctx).WorkflowExecution.ID, key),
+		WaitForCancellation: true,
+		// Cancel rather than terminate the review if we are closed, so that it releases its researcher.
+		ParentClosePolicy: enums.PARENT_CLOSE_POLICY_REQUEST_CANCEL,
+	})
+
+	return workflow.ExecuteChildWorkflow(ctx, ResearcherReview, input)
+}
@@ -0,0 +1,61 @@
+package workflows_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/temporalio/background-checks/activities"
+	"github.com/temporalio/background-checks/workflows"
+	"go.temporal.io/sdk/testsuite"
+)
+
+func TestResearcherReviewWorkflow(t *testing.T) {
+	s := testsuite.WorkflowTestSuite{}
+	env := s.NewTestWorkflowEnvironment()
+	var a *activities.Activities
+
+	mockResearcherPool(env, "researcher1@example.com")
+
+	var token string
+	env.OnActivity(a.SendResearcherReviewRequestEmail, mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, input *activities.SendResearcherReviewRequestEmailInput) (*activities.SendResearcherReviewRequestEmailResult, error) {
+			token = input.Token
+			return &activities.SendResearcherReviewRequestEmailResult{}, nil
+		},
+	)
+
+	env.RegisterDelayedCallback(
+		func() {
+			v, err := env.QueryWorkflow(workflows.ResearcherReviewDetailsQuery, token)
+			assert.NoError(t, err)
+
+			var details workflows.ResearcherReviewDetails
+			assert.NoError(t, v.Get(&details))
+			assert.Equal(t, "Is this right?", details.Question)
+
+			_, err = env.QueryWorkflow(workflows.ResearcherReviewDetailsQuery, "revoked")
+			assert.Error(t, err)
+
+			env.SignalWorkflow(
+				workflows.ResearcherReviewSubmissionSignalName,
+				workflows.ResearcherReviewSubmissionSignal{Confirmed: true, Notes: "Checked", Token: token},
+			)
+		},
+		time.Minute,
+	)
+
+	env.ExecuteWorkflow(workflows.ResearcherReview, &workflows.ResearcherReviewWorkflowInput{
+		Skill:    workflows.ResearcherSkillEducation,
+		Subject:  "Education Verification",
+		Question: "Is this right?",
+	})
+
+	var result workflows.ResearcherReviewWorkflowResult
+	err := env.GetWorkflowResult(&result)
+	assert.NoError(t, err)
+
+	assert.Equal(t, workflows.ResearcherReviewWorkflowResult{Confirmed: true, Notes: "Checked", Researcher: "researcher1@example.com"}, result)
+}
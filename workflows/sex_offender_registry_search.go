@@ -0,0 +1,142 @@
+package workflows
+
+import (
+	"fmt"
+
+	"github.com/temporalio/background-checks/activities"
+	"go.temporal.io/sdk/workflow"
+)
+
+const (
+	// SexOffenderMatchConfidenceThreshold is the confidence at or above which a registry match is reported
+	// without being confirmed by a researcher.
+	SexOffenderMatchConfidenceThreshold = 0.9
+
+	SexOffenderRegistrySourceRegistry   = "registry"
+	SexOffenderRegistrySourceResearcher = "researcher"
+)
+
+type SexOffenderRegistrySearchWorkflowInput struct {
+	FullName string
+	Aliases  []string
+	DOB      string
+}
+
+// SexOffenderRegistryFinding is a registry entry that has been accepted as being the candidate.
+type SexOffenderRegistryFinding struct {
+	Name       string
+	DOB        string
+	State      string
+	Offense    string
+	Confidence float64
+	// MatchedName is the candidate's name the entry was found under.
+	MatchedName string
+	// Source says whether the match was confident enough to report as is, or was confirmed by a researcher.
+	Source string
+	Notes  string
+}
+
+type SexOffenderRegistrySearchWorkflowResult struct {
+	Matches []SexOffenderRegistryFinding
+	// DismissedMatches is the number of possible matches a researcher decided were not the candidate.
+	DismissedMatches int
+}
+
+// @@@SNIPSTART background-checks-sex-offender-registry-workflow-definition
+
+// SexOffenderRegistrySearch is a Workflow Definition that searches the national sex offender registry for the
+// candidate by date of birth and each of their names. Each possible match comes with a confidence level: confident matches are
+// reported directly, while weaker matches are sent to a researcher to confirm so that a namesake doesn't end up
+// in the candidate's report.
+// This is executed as a Child Workflow by the main Background Check.
+func SexOffenderRegistrySearch(ctx workflow.Context, input *SexOffenderRegistrySearchWorkflowInput) (*SexOffenderRegistrySearchWorkflowResult, error) {
+	var result SexOffenderRegistrySearchWorkflowResult
+
+	ctx = withVendorOptions(ctx, VendorSexOffenderRegistry)
+
+	// The same registry entry may be found under more than one of the candidate's names,
+	// so entries are only kept the first time they are found.
+	var matches []activities.SexOffenderRegistryMatch
+	var matchedNames []string
+	seen := make(map[activities.SexOffenderRegistryMatch]bool)
+
+	for _, name := range candidateNames(input.FullName, input.Aliases) {
+		var lookup activities.SexOffenderRegistryLookupResult
+		err := executeVendorActivity[activities.SexOffenderRegistryLookupResult](ctx, a.SexOffenderRegistryLookup, activities.SexOffenderRegistryLookupInput{
+			FullName: name,
+			DOB:      input.DOB,
+		}).Get(ctx, &lookup)
+		if err != nil {
+			return &result, err
+		}
+
+		for _, match := range lookup.Matches {
+			key := match
+			key.Confidence = 0
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			matches = append(matches, match)
+			matchedNames = append(matchedNames, name)
+		}
+	}
+
+	reviews := make(map[int]workflow.ChildWorkflowFuture)
+
+	for i, match := range matches {
+		if match.Confidence >= SexOffenderMatchConfidenceThreshold {
+			continue
+		}
+
+		reviews[i] = startResearcherReview(ctx, fmt.Sprintf("sexoffender-%d", i+1), ResearcherReviewWorkflowInput{
+			Skill:        ResearcherSkillCriminalRecords,
+			Jurisdiction: match.State,
+			Subject:      "Sex Offender Registry Match",
+			Question:     fmt.Sprintf("Is the registry entry for %s (born %s) the candidate %s (born %s)?", match.Name, match.DOB, input.FullName, input.DOB),
+			Details: map[string]string{
+				"Candidate":     input.FullName,
+				"Searched As":   matchedNames[i],
+				"Candidate DOB": input.DOB,
+				"Registry Name": match.Name,
+				"Registry DOB":  match.DOB,
+				"State":         match.State,
+				"Offense":       match.Offense,
+				"Confidence":    fmt.Sprintf("%.0f%%", match.Confidence*100),
+			},
+		})
+	}
+
+	for i, match := range matches {
+		finding := SexOffenderRegistryFinding{
+			Name:        match.Name,
+			DOB:         match.DOB,
+			State:       match.State,
+			Offense:     match.Offense,
+			Confidence:  match.Confidence,
+			MatchedName: matchedNames[i],
+			Source:      SexOffenderRegistrySourceRegistry,
+		}
+
+		if review, ok := reviews[i]; ok {
+			var r ResearcherReviewWorkflowResult
+			err := review.Get(ctx, &r)
+			if err != nil {
+				return &result, err
+			}
+			if !r.Confirmed {
+				result.DismissedMatches++
+				continue
+			}
+
+			finding.Source = SexOffenderRegistrySourceResearcher
+			finding.Notes = r.Notes
+		}
+
+		result.Matches = append(result.Matches, finding)
+	}
+
+	return &result, nil
+}
+
+// @@@SNIPEND
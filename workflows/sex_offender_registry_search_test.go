@@ -0,0 +1,59 @@
+package workflows_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/temporalio/background-checks/activities"
+	"github.com/temporalio/background-checks/workflows"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+)
+
+func TestSexOffenderRegistrySearchWorkflow(t *testing.T) {
+	s := testsuite.WorkflowTestSuite{}
+	env := s.NewTestWorkflowEnvironment()
+	var a *activities.Activities
+
+	env.RegisterWorkflow(workflows.ResearcherReview)
+
+	env.OnActivity(a.SexOffenderRegistryLookup, mock.Anything, &activities.SexOffenderRegistryLookupInput{FullName: "John Smith", DOB: "1981-07-22"}).Return(
+		&activities.SexOffenderRegistryLookupResult{
+			Matches: []activities.SexOffenderRegistryMatch{
+				{Name: "John Smith", DOB: "1981-07-22", State: "FL", Offense: "Offense A", Confidence: 0.98},
+				{Name: "J. Smith", DOB: "1981-07-22", State: "OH", Offense: "Offense B", Confidence: 0.75},
+				{Name: "John Smith", DOB: "1981-02-01", State: "TX", Offense: "Offense C", Confidence: 0.6},
+			},
+		}, nil,
+	)
+
+	var reviews []workflows.ResearcherReviewWorkflowInput
+	env.OnWorkflow(workflows.ResearcherReview, mock.Anything, mock.Anything).Return(
+		func(ctx workflow.Context, input *workflows.ResearcherReviewWorkflowInput) (*workflows.ResearcherReviewWorkflowResult, error) {
+			reviews = append(reviews, *input)
+			if input.Jurisdiction == "OH" {
+				return &workflows.ResearcherReviewWorkflowResult{Confirmed: true, Notes: "Confirmed with court records"}, nil
+			}
+			return &workflows.ResearcherReviewWorkflowResult{Confirmed: false}, nil
+		},
+	)
+
+	env.ExecuteWorkflow(workflows.SexOffenderRegistrySearch, &workflows.SexOffenderRegistrySearchWorkflowInput{FullName: "John Smith", DOB: "1981-07-22"})
+
+	var result workflows.SexOffenderRegistrySearchWorkflowResult
+	err := env.GetWorkflowResult(&result)
+	assert.NoError(t, err)
+
+	// Only the low confidence matches are sent to a researcher.
+	assert.Len(t, reviews, 2)
+	for _, review := range reviews {
+		assert.Equal(t, workflows.ResearcherSkillCriminalRecords, review.Skill)
+	}
+
+	assert.Equal(t, []workflows.SexOffenderRegistryFinding{
+		{Name: "John Smith", DOB: "1981-07-22", State: "FL", Offense: "Offense A", Confidence: 0.98, MatchedName: "John Smith", Source: workflows.SexOffenderRegistrySourceRegistry},
+		{Name: "J. Smith", DOB: "1981-07-22", State: "OH", Offense: "Offense B", Confidence: 0.75, MatchedName: "John Smith", Source: workflows.SexOffenderRegistrySourceResearcher, Notes: "Confirmed with court records"},
+	}, result.Matches)
+	assert.Equal(t, 1, result.DismissedMatches)
+}
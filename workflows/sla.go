@@ -0,0 +1,125 @@
+package workflows
+
+import (
+	"time"
+
+	"github.com/temporalio/background-checks/activities"
+	"go.temporal.io/sdk/workflow"
+)
+
+// TurnaroundSLA is how long a background check should take once the candidate has accepted it.
+type TurnaroundSLA struct {
+	// Warnings are the points, measured from acceptance, at which the Hiring Manager and support are told the check
+	// is running late and the check is marked as delayed.
+	Warnings []time.Duration
+	// Deadline is when the check should be complete, measured from acceptance. Zero means the check has no SLA.
+	Deadline time.Duration
+	// ReleasePartialReport sends the Hiring Manager the report once the deadline has passed, listing the searches
+	// that are still outstanding, rather than waiting for them to complete.
+	ReleasePartialReport bool
+}
+
+// outstandingSearches returns the names of the searches that have not yet completed or failed.
+func (w *backgroundCheckWorkflow) outstandingSearches() []string {
+	var outstanding []string
+	for _, search := range w.Searches {
+		if search.Status == SearchStatusPending || search.Status == SearchStatusRunning {
+			outstanding = append(outstanding, search.Name)
+		}
+	}
+	return outstanding
+}
+
+// sendSLAWarningEmail lets the Hiring Manager and support know that the check is running late.
+func (w *backgroundCheckWorkflow) sendSLAWarningEmail(ctx workflow.Context, deadline time.Time) error {
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: time.Minute,
+	})
+
+	f := workflow.ExecuteActivity(ctx, a.SendSLAWarningEmail, activities.SendSLAWarningEmailInput{
+		Email:       w.Email,
+		Token:       TokenForWorkflow(ctx),
+		Deadline:    deadline,
+		Outstanding: w.outstandingSearches(),
+	})
+	return f.Get(ctx, nil)
+}
+
+// enforceSLA runs alongside the rest of the check, from the candidate's acceptance until the report is sent.
+// At each of the SLA's warning points the check is marked as delayed, and the Hiring Manager and support are
+// emailed. If the package allows it, the report is released once the deadline has passed, without waiting for
+// the searches that are still outstanding.
+func (w *backgroundCheckWorkflow) enforceSLA(ctx workflow.Context, sla TurnaroundSLA) {
+	if sla.Deadline == 0 {
+		return
+	}
+
+	workflow.Go(ctx, func(ctx workflow.Context) {
+		accepted := workflow.Now(ctx)
+		deadline := accepted.Add(sla.Deadline)
+		reportSent := func() bool { return w.ReportVersion > 0 }
+
+		for _, warning := range sla.Warnings {
+			sent, err := workflow.AwaitWithTimeout(ctx, accepted.Add(warning).Sub(workflow.Now(ctx)), reportSent)
+			if err != nil || sent {
+				return
+			}
+
+			w.logger.Warn("Background check is running late", "outstanding", w.outstandingSearches())
+			w.Delayed = true
+			err = w.pushStatus(ctx, "delayed")
+			if err != nil {
+				w.logger.Error("Unable to mark check as delayed", "error", err)
+			}
+			err = w.sendSLAWarningEmail(ctx, deadline)
+			if err != nil {
+				w.logger.Error("Unable to send SLA warning email", "error", err)
+			}
+		}
+
+		sent, err := workflow.AwaitWithTimeout(ctx, deadline.Sub(workflow.Now(ctx)), reportSent)
+		if err != nil || sent {
+			return
+		}
+
+		w.logger.Warn("Background check has passed its deadline", "outstanding", w.outstandingSearches())
+		if sla.ReleasePartialReport {
+			w.releaseReport.Set(nil, nil)
+		}
+	})
+}
+
+// ReportOutcomeLateResult is the outcome of a report amendment that adds the result of a search that completed
+// after a partial report was released.
+const ReportOutcomeLateResult = "late_result"
+
+// amendReportWithLateResult publishes a new version of the report once a search that was still outstanding when the
+// report was released has completed, and lets the Hiring Manager know that the report has changed.
+func (w *backgroundCheckWorkflow) amendReportWithLateResult(ctx workflow.Context, name string) {
+	w.ReportVersion++
+
+	amendment := ReportAmendment{
+		Version:    w.ReportVersion,
+		SearchName: name,
+		Outcome:    ReportOutcomeLateResult,
+		Current:    w.SearchResults[name],
+		AmendedAt:  workflow.Now(ctx),
+	}
+	if searchErr, ok := w.SearchErrors[name]; ok {
+		amendment.Current = searchErr
+	}
+	w.ReportHistory = append(w.ReportHistory, amendment)
+
+	err := w.sendReportEmail(ctx, activities.HiringManagerEmail, true)
+	if err != nil {
+		w.logger.Error("Failed to send amended report", "name", name, "error", err)
+	}
+}
+
+// waitForLateSearches waits for any searches that were still outstanding when the report was released, so that
+// their results are added to the report rather than lost when the check ends.
+func (w *backgroundCheckWorkflow) waitForLateSearches(ctx workflow.Context) error {
+	return workflow.Await(ctx, func() bool {
+		return w.searchesPending() == 0
+	})
+}
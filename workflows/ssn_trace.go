@@ -1,8 +1,6 @@
 package workflows
 
 import (
-	"time"
-
 	"github.com/temporalio/background-checks/activities"
 	"go.temporal.io/sdk/workflow"
 )
@@ -13,25 +11,45 @@ type SSNTraceWorkflowInput struct {
 }
 
 type SSNTraceWorkflowResult struct {
-	SSNIsValid     bool
-	KnownAddresses []string
+	SSNIsValid bool
+	// KnownAddresses is the candidate's address history, with their current address first.
+	KnownAddresses []KnownAddress
+	// NamesOnRecord, DOBOnRecord and IssuedYear are the identity the SSN is registered to.
+	// They are empty if the SSN administration has no record of the SSN.
+	NamesOnRecord []string
+	DOBOnRecord   string
+	IssuedYear    int
 }
 
 // @@@SNIPSTART background-checks-ssn-trace-workflow-definition
 
 // SSNTrace is a Workflow Definition that calls for the execution of a single Activity.
+// The address history the trace returns is parsed and tidied up before it is used by the searches,
+// and any addresses that can't be used are logged and dropped.
 // This is executed as a Child Workflow by the main Background Check.
 func SSNTrace(ctx workflow.Context, input *SSNTraceWorkflowInput) (*SSNTraceWorkflowResult, error) {
 	var result activities.SSNTraceResult
 
-	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
-		StartToCloseTimeout: time.Minute,
-	})
+	logger := workflow.GetLogger(ctx)
+
+	ctx = withVendorOptions(ctx, VendorSSNTrace)
 
-	f := workflow.ExecuteActivity(ctx, a.SSNTrace, SSNTraceWorkflowInput(*input))
+	f := executeVendorActivity[activities.SSNTraceResult](ctx, a.SSNTrace, activities.SSNTraceInput(*input))
 
 	err := f.Get(ctx, &result)
-	r := SSNTraceWorkflowResult(result)
+
+	addresses, problems := NormalizeAddressHistory(result.KnownAddresses)
+	for _, problem := range problems {
+		logger.Warn("Dropping address from SSN trace", "error", problem)
+	}
+
+	r := SSNTraceWorkflowResult{
+		SSNIsValid:     result.SSNIsValid,
+		KnownAddresses: addresses,
+		NamesOnRecord:  result.NamesOnRecord,
+		DOBOnRecord:    result.DOBOnRecord,
+		IssuedYear:     result.IssuedYear,
+	}
 	return &r, err
 }
 
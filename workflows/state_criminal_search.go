@@ -1,55 +1,65 @@
 package workflows
 
 import (
-	"time"
-
 	"github.com/temporalio/background-checks/activities"
 	"go.temporal.io/sdk/workflow"
 )
 
 type StateCriminalSearchWorkflowInput struct {
 	FullName       string
-	KnownAddresses []string
+	Aliases        []string
+	KnownAddresses []KnownAddress
 }
 
 type StateCriminalSearchWorkflowResult struct {
 	Crimes []string
+	// Findings are the crimes along with the name each was found under.
+	Findings []NameFinding
+	// Completeness says whether every name was searched in every state, see SearchCompletenessComplete.
+	Completeness string
+	// Gaps are the names and states that could not be searched.
+	Gaps []SearchGap
 }
 
 // @@@SNIPSTART background-checks-state-criminal-workflow-definition
 
 // StateCriminalSearch is a Workflow Definition that calls for the execution an Activity for
-// each address associated with the Candidate.
+// each state the Candidate has lived in, under each of the Candidate's names.
 // This is executed as a Child Workflow by the main Background Check.
 func StateCriminalSearch(ctx workflow.Context, input *StateCriminalSearchWorkflowInput) (*StateCriminalSearchWorkflowResult, error) {
 	var result StateCriminalSearchWorkflowResult
 
-	name := input.FullName
-	knownaddresses := input.KnownAddresses
-	var crimes []string
+	logger := workflow.GetLogger(ctx)
 
-	for _, address := range knownaddresses {
-		activityInput := activities.StateCriminalSearchInput{
-			FullName: name,
-			Address:  address,
-		}
-		var activityResult activities.StateCriminalSearchResult
+	names := candidateNames(input.FullName, input.Aliases)
+	// Each state's records only need to be searched once, so search from the latest address in each state.
+	knownaddresses := latestAddressInEachState(input.KnownAddresses)
+
+	ctx = withVendorOptions(ctx, VendorStateRepositories)
 
-		ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
-			StartToCloseTimeout: time.Minute,
-		})
+	for _, name := range names {
+		for _, address := range knownaddresses {
+			activityInput := activities.StateCriminalSearchInput{
+				FullName: name,
+				Address:  address.String(),
+			}
+			var activityResult activities.StateCriminalSearchResult
 
-		statecheck := workflow.ExecuteActivity(ctx, a.StateCriminalSearch, activityInput)
+			statecheck := executeVendorActivity[activities.StateCriminalSearchResult](ctx, a.StateCriminalSearch, activityInput)
 
-		err := statecheck.Get(ctx, &activityResult)
-		if err == nil {
-			crimes = append(crimes, activityResult.Crimes...)
+			err := statecheck.Get(ctx, &activityResult)
+			if err != nil {
+				logger.Error("State criminal search failed", "name", name, "state", address.State, "error", err)
+				result.Gaps = append(result.Gaps, newSearchGap(name, address.State, err))
+				continue
+			}
+			result.Findings = addFindings(result.Findings, name, activityResult.Crimes)
 		}
 	}
-	result.Crimes = crimes
+	result.Crimes = findingValues(result.Findings)
+	result.Completeness = searchCompleteness(len(names)*len(knownaddresses), result.Gaps)
 
-	r := StateCriminalSearchWorkflowResult(result)
-	return &r, nil
+	return &result, nil
 }
 
 // @@@SNIPEND
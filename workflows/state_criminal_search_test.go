@@ -0,0 +1,81 @@
+package workflows_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/temporalio/background-checks/activities"
+	"github.com/temporalio/background-checks/workflows"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+)
+
+func TestStateCriminalSearchWorkflowFailed(t *testing.T) {
+	s := testsuite.WorkflowTestSuite{}
+	env := s.NewTestWorkflowEnvironment()
+
+	var searched []string
+	env.RegisterActivityWithOptions(
+		func(ctx context.Context, input *activities.StateCriminalSearchInput) (*activities.StateCriminalSearchResult, error) {
+			searched = append(searched, input.Address)
+			return nil, temporal.NewNonRetryableApplicationError("repository unavailable", "VendorUnavailable", errors.New("unavailable"))
+		},
+		activity.RegisterOptions{Name: "StateCriminalSearch"},
+	)
+
+	env.ExecuteWorkflow(workflows.StateCriminalSearch, &workflows.StateCriminalSearchWorkflowInput{
+		FullName: "John Smith",
+		KnownAddresses: []workflows.KnownAddress{
+			{Address: "1 E. 161 St", City: "Bronx", State: "NY", ZipCode: "10451"},
+			{Address: "1060 W. Addison St", City: "Chicago", State: "IL", ZipCode: "60613"},
+			{Address: "123 Broadway", City: "New York", State: "NY", ZipCode: "10011"},
+		},
+	})
+
+	var result workflows.StateCriminalSearchWorkflowResult
+	err := env.GetWorkflowResult(&result)
+	assert.NoError(t, err)
+
+	// Each state is only searched once, from the latest address there.
+	assert.Equal(t, []string{"1 E. 161 St, Bronx, NY 10451", "1060 W. Addison St, Chicago, IL 60613"}, searched)
+	assert.Empty(t, result.Crimes)
+	assert.Equal(t, workflows.SearchCompletenessFailed, result.Completeness)
+	var jurisdictions []string
+	for _, gap := range result.Gaps {
+		assert.Equal(t, "John Smith", gap.Name)
+		assert.Equal(t, "VendorUnavailable", gap.Type)
+		assert.NotEmpty(t, gap.Error)
+		jurisdictions = append(jurisdictions, gap.Jurisdiction)
+	}
+	assert.Equal(t, []string{"NY", "IL"}, jurisdictions)
+}
+
+func TestStateCriminalSearchWorkflowNoAddresses(t *testing.T) {
+	s := testsuite.WorkflowTestSuite{}
+	env := s.NewTestWorkflowEnvironment()
+
+	searched := 0
+	env.RegisterActivityWithOptions(
+		func(ctx context.Context, input *activities.StateCriminalSearchInput) (*activities.StateCriminalSearchResult, error) {
+			searched++
+			return &activities.StateCriminalSearchResult{}, nil
+		},
+		activity.RegisterOptions{Name: "StateCriminalSearch"},
+	)
+
+	env.ExecuteWorkflow(workflows.StateCriminalSearch, &workflows.StateCriminalSearchWorkflowInput{
+		FullName: "John Smith",
+	})
+
+	var result workflows.StateCriminalSearchWorkflowResult
+	err := env.GetWorkflowResult(&result)
+	assert.NoError(t, err)
+
+	// With no known addresses there are no states to search, which is not the same as a clear result.
+	assert.Equal(t, 0, searched)
+	assert.Empty(t, result.Crimes)
+	assert.Equal(t, workflows.SearchCompletenessFailed, result.Completeness)
+}
@@ -0,0 +1,89 @@
+package workflows
+
+import (
+	"fmt"
+
+	"go.temporal.io/sdk/workflow"
+)
+
+const (
+	UpgradeSignalName = "upgrade"
+)
+
+// ValidateUpgrade returns an error if a check in the given state can't be upgraded to the named package.
+// A package is only an upgrade if it includes every search in the check's current package, as searches that have
+// been started can't be taken back. Checks that have been cancelled, or whose report has been sent, can't be upgraded.
+// The upgrade signal doesn't report back, so the API uses this to reject upgrades the workflow would ignore.
+func ValidateUpgrade(state *BackgroundCheckState, tier string) error {
+	pkg, err := LookupPackage(tier)
+	if err != nil {
+		return err
+	}
+
+	if state.Cancellation != nil {
+		return fmt.Errorf("the check has been cancelled")
+	}
+	if state.ReportVersion > 0 {
+		return fmt.Errorf("the check's searches have completed")
+	}
+
+	current, err := LookupPackage(state.Tier)
+	if err != nil {
+		return err
+	}
+	if current.Name == pkg.Name {
+		return fmt.Errorf("the check already uses package %s", pkg.Name)
+	}
+
+	included := make(map[string]bool, len(pkg.Searches))
+	for _, search := range pkg.Searches {
+		included[search.Name] = true
+	}
+	for _, search := range current.Searches {
+		if !included[search.Name] {
+			return fmt.Errorf("package %s does not include %s, so is not an upgrade from %s", pkg.Name, search.Name, current.Name)
+		}
+	}
+
+	return nil
+}
+
+// upgrade switches the check to a different package.
+// If the searches are already running, any searches in the new package that are not yet running are started immediately.
+// Otherwise the new package's searches are started once the candidate accepts the check.
+func (w *backgroundCheckWorkflow) upgrade(ctx workflow.Context, tier string) {
+	if w.searchesDone {
+		w.logger.Warn("Ignoring upgrade after searches have completed", "tier", tier)
+		return
+	}
+
+	err := ValidateUpgrade(&w.BackgroundCheckState, tier)
+	if err != nil {
+		w.logger.Warn("Ignoring upgrade", "tier", tier, "error", err)
+		return
+	}
+
+	pkg, err := LookupPackage(tier)
+	if err != nil {
+		return
+	}
+
+	w.Tier = pkg.Name
+
+	if w.searchesStarted {
+		w.startSearches(ctx, pkg)
+	}
+}
+
+// handleUpgrades applies each upgrade the Hiring Manager requests while the check is running.
+func (w *backgroundCheckWorkflow) handleUpgrades(ctx workflow.Context) {
+	ch := workflow.GetSignalChannel(ctx, UpgradeSignalName)
+
+	workflow.Go(ctx, func(ctx workflow.Context) {
+		for {
+			var upgrade UpgradeSignal
+			ch.Receive(ctx, &upgrade)
+			w.upgrade(ctx, upgrade.Tier)
+		}
+	})
+}
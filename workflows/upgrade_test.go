@@ -0,0 +1,37 @@
+package workflows_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/temporalio/background-checks/workflows"
+)
+
+func TestValidateUpgrade(t *testing.T) {
+	tests := []struct {
+		name  string
+		state workflows.BackgroundCheckState
+		tier  string
+		valid bool
+	}{
+		{name: "upgrade", state: workflows.BackgroundCheckState{Tier: "standard"}, tier: "driver", valid: true},
+		{name: "full to extended", state: workflows.BackgroundCheckState{Tier: "full"}, tier: "extended", valid: true},
+		{name: "unknown package", state: workflows.BackgroundCheckState{Tier: "standard"}, tier: "platinum"},
+		{name: "same package", state: workflows.BackgroundCheckState{Tier: "driver"}, tier: "driver"},
+		// The full package has no drug screen, so it would drop one of the driver package's searches.
+		{name: "downgrade", state: workflows.BackgroundCheckState{Tier: "driver"}, tier: "full"},
+		{name: "report sent", state: workflows.BackgroundCheckState{Tier: "standard", ReportVersion: 1}, tier: "driver"},
+		{name: "cancelled", state: workflows.BackgroundCheckState{Tier: "standard", Cancellation: &workflows.Cancellation{}}, tier: "driver"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := workflows.ValidateUpgrade(&tt.state, tt.tier)
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,303 @@
+package workflows
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/temporalio/background-checks/activities"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// Vendors are the third parties we call to carry out searches.
+const (
+	VendorSSNTrace            = "ssn-trace"
+	VendorFederalCourts       = "federal-courts"
+	VendorStateRepositories   = "state-repositories"
+	VendorCountyCourts        = "county-courts"
+	VendorDMV                 = "dmv"
+	VendorEducation           = "education"
+	VendorLicensing           = "licensing"
+	VendorSexOffenderRegistry = "sex-offender-registry"
+	VendorCreditBureau        = "credit-bureau"
+	VendorDrugScreenLab       = "drug-screen-lab"
+	VendorInternational       = "international"
+	VendorWatchlist           = "watchlist"
+	// VendorResearchers is the service we use to send work to our researchers, rather than a search vendor.
+	VendorResearchers = "researchers"
+)
+
+// VendorPolicy is how we call one of our vendors. Each attempt is limited by StartToCloseTimeout, and failed
+// attempts are retried with backoff until ScheduleToCloseTimeout, when we give up and the search reports the
+// failure. Requests the vendor rejects as invalid are never retried.
+type VendorPolicy struct {
+	StartToCloseTimeout time.Duration
+	// ScheduleToCloseTimeout of zero means attempts are retried until the search's own deadline, if it has one.
+	ScheduleToCloseTimeout time.Duration
+	InitialInterval        time.Duration
+	MaximumInterval        time.Duration
+	// MaximumAttempts limits the number of attempts as well as the timeout. Zero means no limit.
+	MaximumAttempts int32
+}
+
+// defaultVendorPolicy is used for any vendor that isn't in VendorPolicies.
+var defaultVendorPolicy = VendorPolicy{
+	StartToCloseTimeout:    time.Minute,
+	ScheduleToCloseTimeout: time.Minute * 10,
+	InitialInterval:        time.Second,
+	MaximumInterval:        time.Minute,
+}
+
+// VendorPolicies holds the policy for each vendor.
+// The worker can override these with LoadVendorPolicies.
+var VendorPolicies = map[string]VendorPolicy{
+	// Everything else depends on the SSN trace, so keep trying for longer.
+	VendorSSNTrace: {
+		StartToCloseTimeout:    time.Minute,
+		ScheduleToCloseTimeout: time.Minute * 30,
+		InitialInterval:        time.Second,
+		MaximumInterval:        time.Minute,
+	},
+	VendorFederalCourts: {
+		StartToCloseTimeout:    time.Minute,
+		ScheduleToCloseTimeout: time.Minute * 10,
+		InitialInterval:        time.Second,
+		MaximumInterval:        time.Minute,
+	},
+	// Some state repositories are only available during office hours.
+	VendorStateRepositories: {
+		StartToCloseTimeout:    time.Minute,
+		ScheduleToCloseTimeout: time.Hour * 12,
+		InitialInterval:        time.Second * 10,
+		MaximumInterval:        time.Minute * 30,
+	},
+	VendorCountyCourts: {
+		StartToCloseTimeout:    time.Minute,
+		ScheduleToCloseTimeout: time.Hour * 12,
+		InitialInterval:        time.Second * 10,
+		MaximumInterval:        time.Minute * 30,
+	},
+	VendorDMV: {
+		StartToCloseTimeout:    time.Minute,
+		ScheduleToCloseTimeout: time.Minute * 10,
+		InitialInterval:        time.Second,
+		MaximumInterval:        time.Minute,
+	},
+	VendorEducation: {
+		StartToCloseTimeout:    time.Minute,
+		ScheduleToCloseTimeout: time.Minute * 10,
+		InitialInterval:        time.Second,
+		MaximumInterval:        time.Minute,
+	},
+	VendorLicensing: {
+		StartToCloseTimeout:    time.Minute,
+		ScheduleToCloseTimeout: time.Minute * 10,
+		InitialInterval:        time.Second,
+		MaximumInterval:        time.Minute,
+	},
+	VendorSexOffenderRegistry: {
+		StartToCloseTimeout:    time.Minute,
+		ScheduleToCloseTimeout: time.Minute * 10,
+		InitialInterval:        time.Second,
+		MaximumInterval:        time.Minute,
+	},
+	// Each credit report pull is billed, so don't hammer the bureau.
+	VendorCreditBureau: {
+		StartToCloseTimeout:    time.Minute,
+		ScheduleToCloseTimeout: time.Minute * 30,
+		InitialInterval:        time.Second * 30,
+		MaximumInterval:        time.Minute * 5,
+		MaximumAttempts:        5,
+	},
+	VendorDrugScreenLab: {
+		StartToCloseTimeout:    time.Minute,
+		ScheduleToCloseTimeout: time.Minute * 10,
+		InitialInterval:        time.Second,
+		MaximumInterval:        time.Minute,
+	},
+	// The international vendor is polled by retrying until each country's search is complete, which can take days.
+	// Each country's search has its own deadline, so there is no ScheduleToCloseTimeout.
+	VendorInternational: {
+		StartToCloseTimeout: time.Minute,
+		InitialInterval:     time.Second * 10,
+		MaximumInterval:     time.Hour,
+	},
+	VendorWatchlist: {
+		StartToCloseTimeout:    time.Minute,
+		ScheduleToCloseTimeout: time.Minute * 10,
+		InitialInterval:        time.Second,
+		MaximumInterval:        time.Minute,
+	},
+	VendorResearchers: {
+		StartToCloseTimeout:    time.Minute,
+		ScheduleToCloseTimeout: time.Minute * 10,
+		InitialInterval:        time.Second,
+		MaximumInterval:        time.Minute,
+	},
+}
+
+// SearchError records why a search failed. Type is the kind of failure, such as activities.VendorErrorRejected for
+// a request the vendor refused, or SearchErrorTimeout if the vendor could not be reached before the search's
+// ScheduleToCloseTimeout.
+type SearchError struct {
+	Type    string
+	Message string
+}
+
+const (
+	SearchErrorTimeout  = "Timeout"
+	SearchErrorCanceled = "Canceled"
+	SearchErrorUnknown  = "Unknown"
+)
+
+// NewSearchError classifies the error returned by a search.
+func NewSearchError(err error) SearchError {
+	result := SearchError{Type: SearchErrorUnknown, Message: err.Error()}
+
+	var applicationErr *temporal.ApplicationError
+	var timeoutErr *temporal.TimeoutError
+	var canceledErr *temporal.CanceledError
+
+	// A timeout wraps the last failure of the activity, which is often an ApplicationError, so it is checked first
+	// to avoid reporting a timed-out search as whatever failed on its last attempt.
+	switch {
+	case errors.As(err, &timeoutErr):
+		result.Type = SearchErrorTimeout
+	case errors.As(err, &applicationErr):
+		result.Type = applicationErr.Type()
+	case errors.As(err, &canceledErr):
+		result.Type = SearchErrorCanceled
+	}
+
+	return result
+}
+
+// vendorPolicyFile is the format of the file read by LoadVendorPolicies. Durations are strings such as "10m".
+type vendorPolicyFile map[string]struct {
+	StartToCloseTimeout    string
+	ScheduleToCloseTimeout string
+	InitialInterval        string
+	MaximumInterval        string
+	MaximumAttempts        int32
+}
+
+// LoadVendorPolicies reads vendor policies from a JSON file, keyed by vendor. Any setting that isn't in the file
+// keeps its current value.
+func LoadVendorPolicies(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var file vendorPolicyFile
+	err = json.Unmarshal(data, &file)
+	if err != nil {
+		return fmt.Errorf("unable to parse vendor policies: %w", err)
+	}
+
+	for vendor, settings := range file {
+		policy := vendorPolicy(vendor)
+
+		durations := []struct {
+			value  string
+			target *time.Duration
+		}{
+			{settings.StartToCloseTimeout, &policy.StartToCloseTimeout},
+			{settings.ScheduleToCloseTimeout, &policy.ScheduleToCloseTimeout},
+			{settings.InitialInterval, &policy.InitialInterval},
+			{settings.MaximumInterval, &policy.MaximumInterval},
+		}
+		for _, d := range durations {
+			if d.value == "" {
+				continue
+			}
+			*d.target, err = time.ParseDuration(d.value)
+			if err != nil {
+				return fmt.Errorf("invalid duration for vendor %s: %w", vendor, err)
+			}
+		}
+		if settings.MaximumAttempts != 0 {
+			policy.MaximumAttempts = settings.MaximumAttempts
+		}
+
+		VendorPolicies[vendor] = policy
+	}
+
+	return nil
+}
+
+// vendorPolicy returns the policy for a vendor.
+func vendorPolicy(vendor string) VendorPolicy {
+	if policy, ok := VendorPolicies[vendor]; ok {
+		return policy
+	}
+	return defaultVendorPolicy
+}
+
+// rateLimitDelay returns how long a vendor that rate limited us asked us to wait, or zero if err is not a rate limit
+// with a delay.
+func rateLimitDelay(err error) time.Duration {
+	var applicationErr *temporal.ApplicationError
+	if !errors.As(err, &applicationErr) || applicationErr.Type() != activities.VendorErrorRateLimited || !applicationErr.HasDetails() {
+		return 0
+	}
+
+	var delay time.Duration
+	if applicationErr.Details(&delay) != nil {
+		return 0
+	}
+	return delay
+}
+
+// executeVendorActivity runs a vendor's activity, like workflow.ExecuteActivity, with a context from
+// withVendorOptions. The future holds the activity's result as a T. If the vendor rate limits us and says how long to
+// wait, the activity is run again once that time has passed, until the policy's ScheduleToCloseTimeout, as the
+// activity's retry policy can't honour the delay.
+func executeVendorActivity[T any](ctx workflow.Context, activity interface{}, args ...interface{}) workflow.Future {
+	future, settable := workflow.NewFuture(ctx)
+
+	var deadline time.Time
+	if timeout := workflow.GetActivityOptions(ctx).ScheduleToCloseTimeout; timeout > 0 {
+		deadline = workflow.Now(ctx).Add(timeout)
+	}
+
+	workflow.Go(ctx, func(ctx workflow.Context) {
+		for {
+			var result T
+			err := workflow.ExecuteActivity(ctx, activity, args...).Get(ctx, &result)
+
+			delay := rateLimitDelay(err)
+			if delay == 0 || ctx.Err() != nil || (!deadline.IsZero() && workflow.Now(ctx).Add(delay).After(deadline)) {
+				settable.Set(result, err)
+				return
+			}
+
+			workflow.GetLogger(ctx).Info("Vendor is rate limiting us, waiting before trying again", "delay", delay)
+			if workflow.Sleep(ctx, delay) != nil {
+				settable.Set(result, err)
+				return
+			}
+		}
+	})
+
+	return future
+}
+
+// withVendorOptions returns a context for calling a vendor's activities, using the vendor's policy.
+func withVendorOptions(ctx workflow.Context, vendor string) workflow.Context {
+	policy := vendorPolicy(vendor)
+
+	return workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout:    policy.StartToCloseTimeout,
+		ScheduleToCloseTimeout: policy.ScheduleToCloseTimeout,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    policy.InitialInterval,
+			BackoffCoefficient: 2,
+			MaximumInterval:    policy.MaximumInterval,
+			MaximumAttempts:    policy.MaximumAttempts,
+		},
+	})
+}
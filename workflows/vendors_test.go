@@ -0,0 +1,128 @@
+package workflows_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/temporalio/background-checks/activities"
+	"github.com/temporalio/background-checks/workflows"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+)
+
+func TestCreditReportSearchWorkflowVendorErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		attempts int
+	}{
+		{
+			name:     "unavailable",
+			err:      temporal.NewApplicationError("Internal Server Error", activities.VendorErrorUnavailable),
+			attempts: int(workflows.VendorPolicies[workflows.VendorCreditBureau].MaximumAttempts),
+		},
+		{
+			name:     "rejected",
+			err:      temporal.NewNonRetryableApplicationError("Bad Request", activities.VendorErrorRejected, nil),
+			attempts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := testsuite.WorkflowTestSuite{}
+			env := s.NewTestWorkflowEnvironment()
+			var a *activities.Activities
+
+			attempts := 0
+			env.OnActivity(a.CreditReportLookup, mock.Anything, mock.Anything).Return(
+				func(ctx context.Context, input *activities.CreditReportLookupInput) (*activities.CreditReportLookupResult, error) {
+					attempts++
+					return nil, tt.err
+				},
+			)
+
+			env.ExecuteWorkflow(workflows.CreditReportSearch, &workflows.CreditReportSearchWorkflowInput{FullName: "John Smith", SSN: "222-22-2222", Consent: true})
+
+			err := env.GetWorkflowError()
+			assert.Error(t, err)
+			assert.Equal(t, tt.attempts, attempts)
+
+			var applicationErr *temporal.ApplicationError
+			assert.ErrorAs(t, err, &applicationErr)
+			assert.Equal(t, applicationErr.Type(), workflows.NewSearchError(err).Type)
+		})
+	}
+}
+
+func TestFederalCriminalSearchWorkflowRateLimited(t *testing.T) {
+	s := testsuite.WorkflowTestSuite{}
+	env := s.NewTestWorkflowEnvironment()
+
+	// The vendor asks us to wait 30 seconds before trying again.
+	var attempts []time.Time
+	env.RegisterActivityWithOptions(
+		func(ctx context.Context, input *activities.FederalCriminalSearchInput) (*activities.FederalCriminalSearchResult, error) {
+			attempts = append(attempts, env.Now())
+			if len(attempts) == 1 {
+				return nil, temporal.NewNonRetryableApplicationError("Too Many Requests", activities.VendorErrorRateLimited, nil, time.Second*30)
+			}
+			return &activities.FederalCriminalSearchResult{Crimes: []string{"Espionage"}}, nil
+		},
+		activity.RegisterOptions{Name: "FederalCriminalSearch"},
+	)
+
+	env.ExecuteWorkflow(workflows.FederalCriminalSearch, &workflows.FederalCriminalSearchWorkflowInput{
+		FullName: "John Smith",
+		KnownAddresses: []workflows.KnownAddress{
+			{Address: "1 E. 161 St", City: "Bronx", State: "NY", ZipCode: "10451"},
+		},
+	})
+
+	var result workflows.FederalCriminalSearchWorkflowResult
+	err := env.GetWorkflowResult(&result)
+	assert.NoError(t, err)
+
+	if assert.Len(t, attempts, 2) {
+		assert.Equal(t, time.Second*30, attempts[1].Sub(attempts[0]))
+	}
+	assert.Equal(t, []string{"Espionage"}, result.Crimes)
+	assert.Equal(t, workflows.SearchCompletenessComplete, result.Completeness)
+}
+
+func TestNewSearchErrorTimeout(t *testing.T) {
+	// A search that timed out is reported as a timeout, not as whatever failed on its last attempt.
+	lastErr := temporal.NewApplicationError("Internal Server Error", activities.VendorErrorUnavailable)
+	err := temporal.NewTimeoutError(enumspb.TIMEOUT_TYPE_SCHEDULE_TO_CLOSE, lastErr)
+
+	assert.Equal(t, workflows.SearchErrorTimeout, workflows.NewSearchError(err).Type)
+}
+
+func TestLoadVendorPolicies(t *testing.T) {
+	original := workflows.VendorPolicies[workflows.VendorFederalCourts]
+	defer func() { workflows.VendorPolicies[workflows.VendorFederalCourts] = original }()
+
+	path := filepath.Join(t.TempDir(), "vendors.json")
+	err := os.WriteFile(path, []byte(`{"federal-courts": {"ScheduleToCloseTimeout": "1h", "MaximumAttempts": 3}}`), 0o600)
+	assert.NoError(t, err)
+
+	err = workflows.LoadVendorPolicies(path)
+	assert.NoError(t, err)
+
+	// Settings that aren't in the file keep their defaults.
+	policy := workflows.VendorPolicies[workflows.VendorFederalCourts]
+	assert.Equal(t, time.Hour, policy.ScheduleToCloseTimeout)
+	assert.Equal(t, int32(3), policy.MaximumAttempts)
+	assert.Equal(t, time.Minute, policy.StartToCloseTimeout)
+
+	err = os.WriteFile(path, []byte(`{"federal-courts": {"InitialInterval": "soon"}}`), 0o600)
+	assert.NoError(t, err)
+	assert.Error(t, workflows.LoadVendorPolicies(path))
+}
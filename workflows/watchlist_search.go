@@ -0,0 +1,43 @@
+package workflows
+
+import (
+	"github.com/temporalio/background-checks/activities"
+	"github.com/temporalio/background-checks/watchlist"
+	"go.temporal.io/sdk/workflow"
+)
+
+type WatchlistSearchWorkflowInput struct {
+	FullName string
+	Aliases  []string
+}
+
+type WatchlistSearchWorkflowResult struct {
+	// Hits are the possible matches against the sanctions and watch lists, best first.
+	Hits []watchlist.Hit
+}
+
+// @@@SNIPSTART background-checks-watchlist-search-workflow-definition
+
+// WatchlistSearch is a Workflow Definition that screens the candidate's name and any aliases against
+// sanctions and watch lists. Names are matched fuzzily, so each hit carries a score for the Hiring Manager
+// to judge how likely it is to be the candidate.
+// This is executed as a Child Workflow by the main Background Check.
+func WatchlistSearch(ctx workflow.Context, input *WatchlistSearchWorkflowInput) (*WatchlistSearchWorkflowResult, error) {
+	var result WatchlistSearchWorkflowResult
+
+	ctx = withVendorOptions(ctx, VendorWatchlist)
+
+	names := append([]string{input.FullName}, input.Aliases...)
+
+	var screen activities.WatchlistScreenResult
+	err := workflow.ExecuteActivity(ctx, a.WatchlistScreen, activities.WatchlistScreenInput{Names: names}).Get(ctx, &screen)
+	if err != nil {
+		return &result, err
+	}
+
+	result.Hits = screen.Hits
+
+	return &result, nil
+}
+
+// @@@SNIPEND
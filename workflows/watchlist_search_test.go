@@ -0,0 +1,33 @@
+package workflows_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/temporalio/background-checks/activities"
+	"github.com/temporalio/background-checks/watchlist"
+	"github.com/temporalio/background-checks/workflows"
+	"go.temporal.io/sdk/testsuite"
+)
+
+func TestWatchlistSearchWorkflow(t *testing.T) {
+	s := testsuite.WorkflowTestSuite{}
+	env := s.NewTestWorkflowEnvironment()
+	var a *activities.Activities
+
+	hits := []watchlist.Hit{
+		{Name: "Victor Melnikov", MatchedName: "Viktor Melnikov", EntryID: "SDN-10001", List: "OFAC SDN", Score: 0.93},
+	}
+
+	env.OnActivity(a.WatchlistScreen, mock.Anything, &activities.WatchlistScreenInput{Names: []string{"John Smith", "Victor Melnikov"}}).Return(
+		&activities.WatchlistScreenResult{Hits: hits}, nil,
+	)
+
+	env.ExecuteWorkflow(workflows.WatchlistSearch, &workflows.WatchlistSearchWorkflowInput{FullName: "John Smith", Aliases: []string{"Victor Melnikov"}})
+
+	var result workflows.WatchlistSearchWorkflowResult
+	err := env.GetWorkflowResult(&result)
+	assert.NoError(t, err)
+	assert.Equal(t, hits, result.Hits)
+}
@@ -4,26 +4,164 @@ import (
 	"encoding/base64"
 	"fmt"
 	"path"
+	"strings"
+	"time"
 
 	"go.temporal.io/sdk/workflow"
 )
 
 type CandidateDetails struct {
 	FullName string
-	Address  string
-	SSN      string
-	DOB      string
-	Employer string
+	// Aliases are other names the candidate has been known by, such as a maiden name.
+	Aliases   []string
+	Address   string
+	SSN       string
+	DOB       string
+	Employer  string
+	Education []EducationRecord
+	Licenses  []LicenseRecord
+	// Countries are the countries outside the US the candidate has lived in, as ISO 3166 alpha-2 codes.
+	Countries  []string
+	References []Reference
+}
+
+// NameFinding is a finding from a search that was run under each of the candidate's names.
+// Name is the name the finding was found under.
+type NameFinding struct {
+	Name    string
+	Finding string
+}
+
+// normalizeName reduces a name to lower case words, for comparing names.
+func normalizeName(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), " ")
+}
+
+// MergeAliases adds names to a candidate's aliases, skipping blanks and any that are already the candidate's
+// full name or one of their aliases.
+func MergeAliases(fullName string, aliases []string, names []string) []string {
+	var result []string
+	seen := map[string]bool{normalizeName(fullName): true}
+
+	for _, name := range append(append([]string{}, aliases...), names...) {
+		name = strings.Join(strings.Fields(name), " ")
+		if name == "" || seen[normalizeName(name)] {
+			continue
+		}
+		seen[normalizeName(name)] = true
+		result = append(result, name)
+	}
+
+	return result
+}
+
+// candidateNames returns all the names a name-based search should be run under: the candidate's full name first,
+// followed by their aliases.
+func candidateNames(fullName string, aliases []string) []string {
+	return append([]string{fullName}, MergeAliases(fullName, aliases, nil)...)
+}
+
+// addFindings records the findings found under name. Findings already found under another name are not repeated.
+func addFindings(findings []NameFinding, name string, found []string) []NameFinding {
+	for _, f := range found {
+		duplicate := false
+		for _, existing := range findings {
+			if existing.Finding == f {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			findings = append(findings, NameFinding{Name: name, Finding: f})
+		}
+	}
+
+	return findings
+}
+
+// findingValues returns the findings without the names they were found under.
+func findingValues(findings []NameFinding) []string {
+	var result []string
+	for _, f := range findings {
+		result = append(result, f.Finding)
+	}
+	return result
+}
+
+const (
+	SearchCompletenessComplete   = "complete"
+	SearchCompletenessIncomplete = "incomplete"
+	SearchCompletenessFailed     = "failed"
+)
+
+// SearchGap is a part of a search that we were unable to carry out, such as a state whose records could not be
+// searched. A search with gaps may have missed findings, so it can't be reported as clear.
+type SearchGap struct {
+	Name string
+	// Jurisdiction is the state or address whose records could not be searched.
+	Jurisdiction string
+	// Type is the kind of failure, as classified by NewSearchError.
+	Type  string
+	Error string
+}
+
+// newSearchGap records a part of a search that failed with err.
+func newSearchGap(name string, jurisdiction string, err error) SearchGap {
+	searchErr := NewSearchError(err)
+	return SearchGap{Name: name, Jurisdiction: jurisdiction, Type: searchErr.Type, Error: searchErr.Message}
+}
+
+// searchCompleteness reports how much of a search made up of the given number of parts was carried out.
+// A search with no parts, such as a state search for a candidate with no known addresses, searched nothing, so it
+// is reported as failed rather than clear.
+func searchCompleteness(parts int, gaps []SearchGap) string {
+	switch {
+	case parts == 0:
+		return SearchCompletenessFailed
+	case len(gaps) == 0:
+		return SearchCompletenessComplete
+	case len(gaps) < parts:
+		return SearchCompletenessIncomplete
+	default:
+		return SearchCompletenessFailed
+	}
+}
+
+// EducationRecord is a qualification the candidate says they hold.
+type EducationRecord struct {
+	Institution    string
+	Degree         string
+	GraduationDate string
+}
+
+// LicenseRecord is a professional license the candidate says they hold, such as an RN or CPA license.
+type LicenseRecord struct {
+	Type   string
+	Number string
+	State  string
+}
+
+// Reference is a professional reference the candidate has given us.
+type Reference struct {
+	Name         string
+	Email        string
+	Company      string
+	Relationship string
 }
 
 type AcceptSubmission struct {
-	Accepted         bool
+	Accepted bool
+	// Expired is set if the candidate didn't respond before the deadline.
+	Expired          bool
 	CandidateDetails CandidateDetails
+	// CreditConsent is the candidate's separate consent to an employment credit report.
+	CreditConsent bool
 }
 
 type AcceptSubmissionSignal struct {
 	Accepted         bool
 	CandidateDetails CandidateDetails
+	CreditConsent    bool
 }
 
 type EmploymentVerificationSubmission struct {
@@ -34,13 +172,61 @@ type EmploymentVerificationSubmission struct {
 type EmploymentVerificationSubmissionSignal struct {
 	EmploymentVerificationComplete bool
 	EmployerVerified               bool
+	// Token identifies the assignment the submission was made for. It is set by the API from the request URL.
+	Token string
 }
 
+type ResearcherReviewSubmissionSignal struct {
+	Confirmed bool
+	Notes     string
+	// Token identifies the assignment the submission was made for. It is set by the API from the request URL.
+	Token string
+}
+
+// ReferenceResponse is a reference's answers to the reference questionnaire.
+type ReferenceResponse struct {
+	// Relationship is how the reference knows the candidate, in their own words.
+	Relationship string
+	YearsKnown   int
+	Strengths    string
+	Improvements string
+	// Rating is the reference's overall rating of the candidate, from 1 to 5.
+	Rating      int
+	WouldRehire bool
+	Comments    string
+}
+
+type ReferenceResponseSignal struct {
+	Response ReferenceResponse
+	// Token identifies the reference the response is from. It is set by the API from the request URL.
+	Token string
+}
+
+type AdjudicationDecisionSignal struct {
+	Decision string
+	Reason   string
+}
+
+type DisputeSubmissionSignal struct {
+	SearchName string
+	Finding    string
+	Reason     string
+}
+
+type UpgradeSignal struct {
+	Tier string
+}
+
+// KnownAddress is an address linked to the candidate by the SSN trace.
 type KnownAddress struct {
 	Address string
 	City    string
 	State   string
 	ZipCode string
+	County  string
+	// From and To are when the address was in use. To is zero for the candidate's current address.
+	From time.Time
+	To   time.Time
 }
 
 func BackgroundCheckWorkflowID(email string) string {
@@ -55,10 +241,42 @@ func EmploymentVerificationWorkflowID(email string) string {
 	return fmt.Sprintf("EmploymentVerification:%s", email)
 }
 
+func ResearcherPoolWorkflowID() string {
+	return "ResearcherPool"
+}
+
+func ResearcherReviewWorkflowID(parentID string, key string) string {
+	return fmt.Sprintf("%s:review-%s", parentID, key)
+}
+
 func SearchWorkflowID(email string, name string) string {
 	return fmt.Sprintf("%s:%s", name, email)
 }
 
+func CountryCriminalSearchWorkflowID(parentID string, country string) string {
+	return fmt.Sprintf("%s:country-%s", parentID, country)
+}
+
+func CountyCriminalSearchWorkflowID(parentID string, state string, county string) string {
+	return fmt.Sprintf("%s:county-%s-%s", parentID, state, strings.ReplaceAll(county, " ", "_"))
+}
+
+func DisputeWorkflowID(email string, id int) string {
+	return fmt.Sprintf("Dispute:%s:%d", email, id)
+}
+
+func ReinvestigationWorkflowID(email string, name string, id int) string {
+	return fmt.Sprintf("%s:reinvestigation-%d", SearchWorkflowID(email, name), id)
+}
+
+func ContinuousMonitoringWorkflowID(email string) string {
+	return fmt.Sprintf("ContinuousMonitoring:%s", email)
+}
+
+func MonitoringSearchWorkflowID(email string, name string, cycle int) string {
+	return fmt.Sprintf("%s:monitoring-%d", SearchWorkflowID(email, name), cycle)
+}
+
 func TokenForWorkflow(ctx workflow.Context) string {
 	info := workflow.GetInfo(ctx)
 
@@ -67,6 +285,16 @@ func TokenForWorkflow(ctx workflow.Context) string {
 	return base64.URLEncoding.EncodeToString([]byte(rawToken))
 }
 
+// TokenForAssignment returns a token for a particular piece of work within a workflow execution.
+// The workflow can revoke the token by replacing the assignment.
+func TokenForAssignment(ctx workflow.Context, assignment string) string {
+	info := workflow.GetInfo(ctx)
+
+	rawToken := path.Join(info.WorkflowExecution.ID, info.WorkflowExecution.RunID) + "#" + assignment
+
+	return base64.URLEncoding.EncodeToString([]byte(rawToken))
+}
+
 func WorkflowFromToken(token string) (string, string, error) {
 	var rawToken []byte
 
@@ -75,8 +303,10 @@ func WorkflowFromToken(token string) (string, string, error) {
 		return "", "", err
 	}
 
-	wfid := path.Dir(string(rawToken))
-	runid := path.Base(string(rawToken))
+	execution, _, _ := strings.Cut(string(rawToken), "#")
+
+	wfid := path.Dir(execution)
+	runid := path.Base(execution)
 
 	return wfid, runid, nil
 }